@@ -34,22 +34,25 @@ func (r *Room) OnDestroy(ctx actor.Context) {
 }
 
 func (r *Room) PushMembers(sender *actor.PID, ctx actor.Context) {
-	request, err := ctx.Request(r.broadcastGroup, router.GetRouteesEnvelope())
-	if err != nil {
-		return
-	}
-
-	routees, ok := request.Message.(*router.Routees)
-	if !ok {
-		return
-	}
-
-	allMembers := make([]string, 0, len(routees.PIDs))
-	for _, pid := range routees.PIDs {
-		allMembers = append(allMembers, pid.ID)
-	}
-
-	ctx.Send(sender, wrap.WrapPushEnvelop("onMembers", &AllMembers{Members: allMembers}))
+	future := ctx.RequestFuture(r.broadcastGroup, router.GetRouteesEnvelope(),
+		ctx.ActorSystem().Config.DefaultRequestTimeout)
+	ctx.ReenterAfter(future, func(res *actor.MessageEnvelope, err error) {
+		if err != nil {
+			return
+		}
+
+		routees, ok := res.Message.(*router.Routees)
+		if !ok {
+			return
+		}
+
+		allMembers := make([]string, 0, len(routees.PIDs))
+		for _, pid := range routees.PIDs {
+			allMembers = append(allMembers, pid.ID)
+		}
+
+		ctx.Send(sender, wrap.WrapPushEnvelop("onMembers", &AllMembers{Members: allMembers}))
+	})
 }
 
 func (r *Room) Join(ctx actor.Context) (*JoinResponse, error) {
@@ -57,7 +60,7 @@ func (r *Room) Join(ctx actor.Context) (*JoinResponse, error) {
 		Code:   0,
 		Result: "success",
 	}
-	go r.PushMembers(ctx.Sender(), ctx)
+	r.PushMembers(ctx.Sender(), ctx)
 	// ctx.Send(ctx.Sender(), actor.WrapResponseEnvelop(msg.ID, response))
 
 	push := wrap.WrapBroadcast(r.app, "onNewUser", &NewUser{Content: fmt.Sprintf("New user: %s", ctx.Sender().String())})
@@ -85,7 +85,7 @@ func main() {
 	rootContext := actor.NewRootContext(system, nil).WithSpawnMiddleware(mw.spawnMiddleware)
 	props := actor.PropsFromFunc(
 		receive,
-		actor.WithReceiverMiddleware(middleware.ReceiveLogger),
+		actor.WithReceiverMiddleware(middleware.ReceiveLogger, middleware.Recover(nil)),
 		actor.WithSenderMiddleware(mw.senderMiddleware),
 		actor.WithSpawnMiddleware(mw.spawnMiddleware),
 	)
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/colin1989/battery/actor"
 	"github.com/colin1989/battery/blog"
@@ -293,6 +294,21 @@ func main() {
 
 	wg.Wait()
 
+	// fan out the four operations at once and wait for all the results
+	results, err := actor.WaitAll(
+		system.Root.RequestFuture(m, actor.WrapEnvelope(&addition{A: 6, B: 3}), time.Second),
+		system.Root.RequestFuture(m, actor.WrapEnvelope(&subtraction{A: 6, B: 3}), time.Second),
+		system.Root.RequestFuture(m, actor.WrapEnvelope(&multiplication{A: 6, B: 3}), time.Second),
+		system.Root.RequestFuture(m, actor.WrapEnvelope(&division{A: 6, B: 3}), time.Second),
+	)
+	if err != nil {
+		blog.Error("Request all", blog.ErrAttr(err))
+	} else {
+		for _, result := range results {
+			blog.Info(fmt.Sprintf(" Request all=[%v] \n", result.Message))
+		}
+	}
+
 	system.Root.Poison(m)
 	system.Shutdown()
 }
@@ -23,6 +23,9 @@ type actorContext struct {
 	receiveTimeout      time.Duration
 	receiveTimeoutTimer *time.Timer
 	envelope            *MessageEnvelope
+	behavior            Behavior
+	pinned              *pinnedWorker
+	startedAt           time.Time
 	state               int32
 }
 
@@ -33,6 +36,7 @@ var (
 	_ basePart        = &actorContext{}
 	_ stopperPart     = &actorContext{}
 	_ Invoker         = &actorContext{}
+	_ Supervisor      = &actorContext{}
 )
 
 func newActorContext(actorSystem *ActorSystem, props *Props, parent *PID) *actorContext {
@@ -40,6 +44,7 @@ func newActorContext(actorSystem *ActorSystem, props *Props, parent *PID) *actor
 		actorSystem: actorSystem,
 		props:       props,
 		parent:      parent,
+		startedAt:   actorSystem.Config.Clock.Now(),
 	}
 	ac.incarnateActor()
 	return ac
@@ -66,6 +71,27 @@ func (ctx *actorContext) Logger() *slog.Logger {
 	return ctx.actorSystem.Logger()
 }
 
+func (ctx *actorContext) Scheduler() *Scheduler {
+	extras := ctx.ensureExtras()
+	if extras.scheduler == nil {
+		extras.scheduler = NewScheduler(ctx.actorSystem)
+	}
+
+	return extras.scheduler
+}
+
+func (ctx *actorContext) Become(receive ReceiveFunc) {
+	ctx.behavior.Become(receive)
+}
+
+func (ctx *actorContext) BecomeStacked(receive ReceiveFunc) {
+	ctx.behavior.BecomeStacked(receive)
+}
+
+func (ctx *actorContext) UnbecomeStacked() {
+	ctx.behavior.UnbecomeStacked()
+}
+
 func (ctx *actorContext) Children() []*PID {
 	if ctx.extras == nil {
 		return make([]*PID, 0)
@@ -83,8 +109,36 @@ func (ctx *actorContext) Respond(response *MessageEnvelope) {
 	ctx.Send(ctx.Sender(), response)
 }
 
-func (ctx *actorContext) Stash() {
-	ctx.ensureExtras().stash(ctx.Message())
+func (ctx *actorContext) Stash() error {
+	return ctx.ensureExtras().stash(ctx.Envelope(), ctx.props.stashCapacity)
+}
+
+func (ctx *actorContext) UnstashAll() {
+	if ctx.extras == nil {
+		return
+	}
+
+	ctx.extras.unstashAll()
+}
+
+// replayUnstashed processes the messages released by UnstashAll before the next message of the mailbox.
+func (ctx *actorContext) replayUnstashed() {
+	if ctx.extras == nil || ctx.extras.unstashing {
+		return
+	}
+
+	ctx.extras.unstashing = true
+	defer func() {
+		ctx.extras.unstashing = false
+	}()
+
+	for {
+		envelope, ok := ctx.extras.popUnstashed()
+		if !ok {
+			return
+		}
+		ctx.InvokeUserMessage(envelope)
+	}
 }
 
 func (ctx *actorContext) Watch(who *PID) {
@@ -123,7 +177,7 @@ func (ctx *actorContext) SetReceiveTimeout(d time.Duration) {
 
 	if d > 0 {
 		if ctx.extras.receiveTimeoutTimer == nil {
-			ctx.extras.initReceiveTimeoutTimer(time.AfterFunc(d, ctx.receiveTimeoutHandler))
+			ctx.extras.initReceiveTimeoutTimer(ctx.actorSystem.Config.Clock.AfterFunc(d, ctx.receiveTimeoutHandler))
 		} else {
 			ctx.extras.resetReceiveTimeoutTimer(d)
 		}
@@ -139,12 +193,39 @@ func (ctx *actorContext) CancelReceiveTimeout() {
 	ctx.receiveTimeout = 0
 }
 
+func (ctx *actorContext) ReenterAfter(future *Future, continuation func(res *MessageEnvelope, err error), opts ...ReenterOption) {
+	config := newReenterConfig(opts...)
+	envelope := ctx.envelope
+	extras := ctx.ensureExtras()
+	generation := extras.reenterGeneration
+	if config.stash {
+		extras.reenterStashing++
+	}
+
+	future.ContinueWith(func(res *MessageEnvelope, err error) {
+		ctx.self.sendSystemMessage(ctx.actorSystem, &reenterContinuation{
+			envelope:   envelope,
+			stash:      config.stash,
+			generation: generation,
+			f: func() {
+				continuation(res, err)
+			},
+		})
+	})
+}
+
+// receiveTimeoutHandler runs on the timer goroutine, the actor state is only touched in handleReceiveTimeout.
 func (ctx *actorContext) receiveTimeoutHandler() {
-	if ctx.extras != nil && ctx.extras.receiveTimeoutTimer != nil {
-		ctx.CancelReceiveTimeout()
-		//ac.Send(ac.self, receiveTimeoutMessage())
-		ctx.self.sendSystemMessage(ctx.actorSystem, receiveTimeoutMessage)
+	ctx.self.sendSystemMessage(ctx.actorSystem, receiveTimeoutMessage)
+}
+
+func (ctx *actorContext) handleReceiveTimeout() {
+	if ctx.extras == nil || ctx.extras.receiveTimeoutTimer == nil {
+		return
 	}
+
+	ctx.CancelReceiveTimeout()
+	ctx.InvokeUserMessage(WrapEnvelope(receiveTimeoutMessage))
 }
 
 //
@@ -180,14 +261,16 @@ func (ctx *actorContext) Send(pid *PID, envelope *MessageEnvelope) {
 	ctx.sendUserMessage(pid, envelope)
 }
 
-func (ctx *actorContext) Request(pid *PID, envelope *MessageEnvelope) (*MessageEnvelope, error) {
-	// TODO: timeout 应该作为配置
-	timeout := time.Second * 5
+func (ctx *actorContext) Request(pid *PID, envelope *MessageEnvelope, opts ...RequestOption) (*MessageEnvelope, error) {
+	return request(ctx, pid, envelope, opts...)
+}
+
+func (ctx *actorContext) RequestFuture(pid *PID, envelope *MessageEnvelope, timeout time.Duration) *Future {
 	future := NewFuture(ctx.actorSystem, timeout)
 	envelope.Sender = future.pid
 
 	ctx.sendUserMessage(pid, envelope)
-	return future.Result()
+	return future
 }
 
 func (ctx *actorContext) Envelope() *MessageEnvelope {
@@ -217,10 +300,12 @@ func (ctx *actorContext) Message() interface{} {
 // Interface: ReceiverContext
 //
 
+// Receive may be called while another message is processed, e.g. by a middleware, so the current one is restored.
 func (ctx *actorContext) Receive(envelope *MessageEnvelope) {
+	previous := ctx.envelope
 	ctx.envelope = envelope
 	ctx.defaultReceive()
-	ctx.envelope = nil
+	ctx.envelope = previous
 }
 
 func (ctx *actorContext) defaultReceive() {
@@ -228,7 +313,11 @@ func (ctx *actorContext) defaultReceive() {
 	case *PoisonPill:
 		ctx.Stop(ctx.self)
 	default:
-		ctx.actor.Receive(ctx)
+		if ctx.behavior.Len() > 0 {
+			ctx.behavior.Receive(ctx)
+		} else {
+			ctx.actor.Receive(ctx)
+		}
 	}
 }
 
@@ -283,7 +372,7 @@ func (ctx *actorContext) Stop(pid *PID) {
 
 // StopFuture will stop actor immediately regardless of existing user messages in mailbox, and return its future.
 func (ctx *actorContext) StopFuture(pid *PID) *Future {
-	future := NewFuture(ctx.actorSystem, 10*time.Second)
+	future := NewFuture(ctx.actorSystem, ctx.actorSystem.Config.DefaultStopTimeout)
 
 	pid.sendSystemMessage(ctx.actorSystem, &Watch{Watcher: future.pid})
 	ctx.Stop(pid)
@@ -297,7 +386,7 @@ func (ctx *actorContext) Poison(pid *PID) {
 
 // PoisonFuture will tell actor to stop after processing current user messages in mailbox, and return its future.
 func (ctx *actorContext) PoisonFuture(pid *PID) *Future {
-	future := NewFuture(ctx.actorSystem, 10*time.Second)
+	future := NewFuture(ctx.actorSystem, ctx.actorSystem.Config.DefaultStopTimeout)
 
 	pid.sendSystemMessage(ctx.actorSystem, &Watch{Watcher: future.pid})
 	ctx.Poison(pid)
@@ -306,22 +395,47 @@ func (ctx *actorContext) PoisonFuture(pid *PID) *Future {
 }
 
 //
-// Interface: stopperPart
+// Interface: Supervisor
+//
+
+func (ctx *actorContext) RestartChildren(pids ...*PID) {
+	for _, pid := range pids {
+		pid.sendSystemMessage(ctx.actorSystem, restartMessage)
+	}
+}
+
+func (ctx *actorContext) StopChildren(pids ...*PID) {
+	for _, pid := range pids {
+		pid.sendSystemMessage(ctx.actorSystem, stopMessage)
+	}
+}
+
+func (ctx *actorContext) ResumeChildren(pids ...*PID) {
+	for _, pid := range pids {
+		pid.sendSystemMessage(ctx.actorSystem, resumeMailboxMessage)
+	}
+}
+
+//
+// Interface: Invoker
 //
 
 func (ctx *actorContext) incarnateActor() {
 	atomic.StoreInt32(&ctx.state, stateAlive)
 	ctx.actor = ctx.props.producer(ctx.actorSystem)
+	ctx.behavior.clear()
 }
 
 func (ctx *actorContext) EscalateFailure(reason interface{}, message interface{}) {
 	ctx.self.sendSystemMessage(ctx.actorSystem, suspendMailboxMessage)
+	// the continuations wait for the decision of the supervisor, see handleContinuation
+	ctx.ensureExtras().suspended = true
 
 	failure := &Failure{
-		Reason: reason,
-		Who:    ctx.self,
-		//RestartStats: ctx.ensureExtras().restartStats(),
-		Message: message,
+		Reason:       reason,
+		Who:          ctx.self,
+		RestartStats: ctx.ensureExtras().restartStats(),
+		Message:      message,
 	}
 
 	if ctx.parent == nil {
@@ -343,6 +457,16 @@ func (ctx *actorContext) InvokeSystemMessage(message SystemMessage) {
 		ctx.handleStop()
 	case *Terminated:
 		ctx.handleTerminated(msg)
+	case *ReceiveTimeout:
+		ctx.handleReceiveTimeout()
+	case *inspectActor:
+		msg.reply <- ctx.info()
+	case *reenterContinuation:
+		ctx.handleContinuation(msg)
+	case *ResumeMailbox:
+		ctx.handleResume()
+	case *Failure:
+		ctx.handleFailure(msg)
 	case *Restart:
 		ctx.handleRestart()
 	default:
@@ -351,8 +475,17 @@ func (ctx *actorContext) InvokeSystemMessage(message SystemMessage) {
 }
 
 func (ctx *actorContext) handleRootFailure(failure *Failure) {
-	//defaultSupervisionStrategy.HandleFailure(ctx.actorSystem, ctx, failure.Who, failure.RestartStats, failure.Reason, failure.Envelope)
-	ctx.Logger().Warn("handleRootFailure", slog.Any("failure", failure))
+	defaultSupervisionStrategy.HandleFailure(ctx.actorSystem, ctx, failure.Who, failure.RestartStats, failure.Reason, failure.Message)
+}
+
+// a child failed, let the supervisor strategy decide its fate.
+func (ctx *actorContext) handleFailure(msg *Failure) {
+	if strategy, ok := ctx.actor.(SupervisorStrategy); ok {
+		strategy.HandleFailure(ctx.actorSystem, ctx, msg.Who, msg.RestartStats, msg.Reason, msg.Message)
+		return
+	}
+
+	ctx.props.getSupervisor().HandleFailure(ctx.actorSystem, ctx, msg.Who, msg.RestartStats, msg.Reason, msg.Message)
 }
 
 func (ctx *actorContext) InvokeUserMessage(envelope *MessageEnvelope) {
@@ -360,12 +493,23 @@ func (ctx *actorContext) InvokeUserMessage(envelope *MessageEnvelope) {
 		return
 	}
 
+	if batch, ok := envelope.Message.(*MessageBatch); ok {
+		ctx.invokeBatch(batch)
+		return
+	}
+
+	// a stashing ReenterAfter is pending, keep user messages until its continuation ran
+	if ctx.extras != nil && ctx.extras.reenterStashing > 0 && isStashable(envelope) {
+		ctx.extras.reenterStash = append(ctx.extras.reenterStash, envelope)
+		return
+	}
+
 	_, msg, _ := UnwrapEnvelope(envelope)
 
 	influenceTimeout := true
 	if ctx.receiveTimeout > 0 {
 		_, influenceTimeout = msg.(NotInfluenceReceiveTimeout)
-		influenceTimeout = !influenceTimeout
+		influenceTimeout = !influenceTimeout && envelope.GetHeader(NotInfluenceReceiveTimeoutHeader) == ""
 
 		if influenceTimeout {
 			ctx.extras.stopReceiveTimeoutTimer()
@@ -377,6 +521,27 @@ func (ctx *actorContext) InvokeUserMessage(envelope *MessageEnvelope) {
 	if ctx.receiveTimeout > 0 && influenceTimeout {
 		ctx.extras.resetReceiveTimeoutTimer(ctx.receiveTimeout)
 	}
+
+	if ctx.extras != nil && len(ctx.extras.unstashed) > 0 {
+		ctx.replayUnstashed()
+	}
+}
+
+// invokeBatch receives the envelopes of batch one by one. When one of them panics, the ones after it are sent to
+// the dead letters instead of being lost with the failed message.
+func (ctx *actorContext) invokeBatch(batch *MessageBatch) {
+	next := 0
+	defer func() {
+		for _, item := range batch.Envelopes[next:] {
+			ctx.actorSystem.DeadLetter.SendUserMessage(ctx.self, item)
+		}
+	}()
+
+	for next < len(batch.Envelopes) {
+		item := batch.Envelopes[next]
+		next++
+		ctx.InvokeUserMessage(item)
+	}
 }
 
 func (ctx *actorContext) processMessage(envelope *MessageEnvelope) {
@@ -391,6 +556,49 @@ func (ctx *actorContext) processMessage(envelope *MessageEnvelope) {
 	ctx.envelope = nil
 }
 
+// run the continuation of ReenterAfter with the message it was registered for.
+// Continuations are dropped once the actor is stopping, or when they belong to an incarnation replaced by a restart.
+// While the failed actor waits for its supervisor they are held, and run if it is resumed.
+func (ctx *actorContext) handleContinuation(msg *reenterContinuation) {
+	if atomic.LoadInt32(&ctx.state) >= stateStopping || msg.generation != ctx.extras.reenterGeneration {
+		return
+	}
+
+	if ctx.extras.suspended {
+		ctx.extras.heldContinuations = append(ctx.extras.heldContinuations, msg)
+		return
+	}
+
+	previous := ctx.envelope
+	ctx.envelope = msg.envelope
+	ctx.runContinuation(msg)
+	ctx.envelope = previous
+	ctx.replayUnstashed()
+}
+
+// the supervisor resumed the failed actor, run the continuations that arrived meanwhile
+func (ctx *actorContext) handleResume() {
+	if ctx.extras == nil || !ctx.extras.suspended {
+		return
+	}
+
+	ctx.extras.suspended = false
+	held := ctx.extras.heldContinuations
+	ctx.extras.heldContinuations = nil
+	for _, msg := range held {
+		ctx.handleContinuation(msg)
+	}
+}
+
+// runContinuation releases the messages held by a stashing continuation even when it panics, the restart replays them then.
+func (ctx *actorContext) runContinuation(msg *reenterContinuation) {
+	if msg.stash {
+		defer ctx.extras.releaseReenterStash(msg.generation)
+	}
+
+	msg.f()
+}
+
 // I am stopping.
 func (ctx *actorContext) handleStop() {
 	if atomic.LoadInt32(&ctx.state) >= stateStopping {
@@ -467,24 +675,34 @@ func (ctx *actorContext) tryRestartOrTerminate() {
 }
 
 func (ctx *actorContext) restart() {
+	if ctx.extras != nil {
+		ctx.extras.resetReenterStash()
+	}
 	ctx.incarnateActor()
 	ctx.self.sendSystemMessage(ctx.actorSystem, resumeMailboxMessage)
 	ctx.InvokeUserMessage(startedMessageEnvelope())
+	ctx.actorSystem.EventStream.Publish(&ActorRestartedEvent{PID: ctx.self})
 
-	for {
-		msg, ok := ctx.extras.popStash()
-		if !ok {
-			break
-		}
-		ctx.InvokeUserMessage(WrapEnvelope(msg))
+	if ctx.extras == nil {
+		return
 	}
+
+	// replay the stash in order, the messages the new incarnation stashes again stay in the same order
+	ctx.extras.unstashAll()
+	ctx.replayUnstashed()
 }
 
 func (ctx *actorContext) finalizeStop() {
 	ctx.actorSystem.ProcessRegistry.Remove(ctx.self)
+	if ctx.props.mailboxStats {
+		ctx.actorSystem.mailboxStats.remove(ctx.self)
+	}
+	if ctx.extras != nil && ctx.extras.scheduler != nil {
+		ctx.extras.scheduler.CancelAll()
+	}
 	ctx.InvokeUserMessage(stoppedMessage())
 
-	otherStopped := &Terminated{Who: ctx.self}
+	otherStopped := &Terminated{Who: ctx.self, Why: TerminatedReason_Stopped}
 	// Notify watchers
 	if ctx.extras != nil {
 		ctx.extras.watchers.ForEach(func(i int, pid *PID) {
@@ -497,4 +715,9 @@ func (ctx *actorContext) finalizeStop() {
 	}
 
 	atomic.StoreInt32(&ctx.state, stateStopped)
+	if ctx.pinned != nil {
+		ctx.pinned.stop()
+	}
+
+	ctx.actorSystem.EventStream.Publish(&ActorStoppedEvent{PID: ctx.self, Parent: ctx.parent, Reason: otherStopped.Why})
 }
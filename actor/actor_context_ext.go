@@ -1,19 +1,27 @@
 package actor
 
 import (
-	"github.com/colin1989/battery/actor/ctxext"
-	"github.com/emirpasic/gods/stacks/linkedliststack"
 	"time"
+
+	"github.com/colin1989/battery/actor/ctxext"
 )
 
 type actorContextExtras struct {
 	children            PIDSet
-	receiveTimeoutTimer *time.Timer
-	//rs                  *RestartStatistics
-	stack      *linkedliststack.Stack
-	watchers   PIDSet
-	context    Context
-	extensions *ctxext.ContextExtensions
+	receiveTimeoutTimer Timer
+	rs                  *RestartStatistics
+	stashed             []*MessageEnvelope
+	unstashed           []*MessageEnvelope
+	unstashing          bool
+	watchers            PIDSet
+	context             Context
+	extensions          *ctxext.ContextExtensions
+	reenterStashing     int
+	reenterStash        []*MessageEnvelope
+	reenterGeneration   int
+	suspended           bool // the actor failed and waits for its supervisor
+	heldContinuations   []*reenterContinuation
+	scheduler           *Scheduler
 }
 
 func newActorContextExtras(context Context) *actorContextExtras {
@@ -25,17 +33,17 @@ func newActorContextExtras(context Context) *actorContextExtras {
 	return this
 }
 
-//func (ctxExt *actorContextExtras) restartStats() *RestartStatistics {
-//	// lazy initialize the child restart stats if this is the first time
-//	// further mutations are handled within "restart"
-//	if ctxExt.rs == nil {
-//		ctxExt.rs = NewRestartStatistics()
-//	}
-//
-//	return ctxExt.rs
-//}
+func (ctxExt *actorContextExtras) restartStats() *RestartStatistics {
+	// lazy initialize the child restart stats if this is the first time
+	// further mutations are handled within "restart"
+	if ctxExt.rs == nil {
+		ctxExt.rs = NewRestartStatistics()
+	}
+
+	return ctxExt.rs
+}
 
-func (ctxExt *actorContextExtras) initReceiveTimeoutTimer(timer *time.Timer) {
+func (ctxExt *actorContextExtras) initReceiveTimeoutTimer(timer Timer) {
 	ctxExt.receiveTimeoutTimer = timer
 }
 
@@ -72,8 +80,9 @@ func (ctxExt *actorContextExtras) removeChild(pid *PID) {
 	ctxExt.children.Remove(pid)
 }
 
+// Children returns a copy, PIDSet.Remove reorders the set in place
 func (ctxExt *actorContextExtras) Children() []*PID {
-	return ctxExt.children.Values()
+	return append([]*PID(nil), ctxExt.children.Values()...)
 }
 
 func (ctxExt *actorContextExtras) watch(watcher *PID) {
@@ -84,20 +93,56 @@ func (ctxExt *actorContextExtras) unwatch(watcher *PID) {
 	ctxExt.watchers.Remove(watcher)
 }
 
-func (ctxExt *actorContextExtras) stash(message interface{}) {
-	if ctxExt.stack == nil {
-		ctxExt.stack = linkedliststack.New()
+// stash keeps envelope until UnstashAll, a capacity of 0 is unbounded
+func (ctxExt *actorContextExtras) stash(envelope *MessageEnvelope, capacity int) error {
+	if capacity > 0 && len(ctxExt.stashed) >= capacity {
+		return ErrStashFull
 	}
-	ctxExt.stack.Push(message)
+	ctxExt.stashed = append(ctxExt.stashed, envelope)
+
+	return nil
 }
 
-func (ctxExt *actorContextExtras) popStash() (interface{}, bool) {
-	if ctxExt.stack == nil || ctxExt.stack.Empty() {
-		return nil, false
+// unstashAll queues the stashed envelopes for replay, after the ones a previous UnstashAll did not replay yet
+func (ctxExt *actorContextExtras) unstashAll() {
+	ctxExt.unstashed = append(ctxExt.unstashed, ctxExt.stashed...)
+	ctxExt.stashed = nil
+}
+
+// releaseReenterStash ends a stashing ReenterAfter, once none is pending the held envelopes are queued for replay.
+// Continuations registered before the last restart are ignored, the restart already released their messages.
+func (ctxExt *actorContextExtras) releaseReenterStash(generation int) {
+	if generation != ctxExt.reenterGeneration || ctxExt.reenterStashing == 0 {
+		return
 	}
-	message, ok := ctxExt.stack.Pop()
-	if !ok {
+
+	ctxExt.reenterStashing--
+	if ctxExt.reenterStashing > 0 {
+		return
+	}
+
+	ctxExt.unstashed = append(ctxExt.unstashed, ctxExt.reenterStash...)
+	ctxExt.reenterStash = nil
+}
+
+// resetReenterStash forgets the pending ReenterAfter calls of the previous incarnation and queues the
+// envelopes they held for replay.
+func (ctxExt *actorContextExtras) resetReenterStash() {
+	ctxExt.reenterGeneration++
+	ctxExt.suspended = false
+	ctxExt.heldContinuations = nil
+	ctxExt.reenterStashing = 0
+	ctxExt.unstashed = append(ctxExt.unstashed, ctxExt.reenterStash...)
+	ctxExt.reenterStash = nil
+}
+
+func (ctxExt *actorContextExtras) popUnstashed() (*MessageEnvelope, bool) {
+	if len(ctxExt.unstashed) == 0 {
 		return nil, false
 	}
-	return message, true
+	envelope := ctxExt.unstashed[0]
+	ctxExt.unstashed[0] = nil
+	ctxExt.unstashed = ctxExt.unstashed[1:]
+
+	return envelope, true
 }
@@ -6,6 +6,7 @@ import "sync/atomic"
 type ActorProcess struct {
 	mailbox Mailbox
 	dead    int32
+	ctx     *actorContext
 }
 
 var _ Process = &ActorProcess{}
@@ -0,0 +1,64 @@
+package actor
+
+// Behavior is a stack of ReceiveFunc, the top one handles the messages.
+// It can be embedded in an actor struct, or driven through Context.Become and friends,
+// in which case it is reset to the actor Receive when the actor restarts.
+type Behavior []ReceiveFunc
+
+func NewBehavior() Behavior {
+	return make(Behavior, 0)
+}
+
+// Become replaces the whole stack with receive
+func (b *Behavior) Become(receive ReceiveFunc) {
+	b.clear()
+	b.push(receive)
+}
+
+// BecomeStacked pushes receive on top of the current behavior
+func (b *Behavior) BecomeStacked(receive ReceiveFunc) {
+	b.push(receive)
+}
+
+// UnbecomeStacked returns to the previous behavior
+func (b *Behavior) UnbecomeStacked() {
+	b.pop()
+}
+
+// Receive passes the message to the active behavior, it is a no-op when the stack is empty.
+func (b *Behavior) Receive(ctx Context) {
+	if receive, ok := b.peek(); ok {
+		receive(ctx)
+	}
+}
+
+func (b *Behavior) Len() int {
+	return len(*b)
+}
+
+func (b *Behavior) clear() {
+	for i := range *b {
+		(*b)[i] = nil
+	}
+
+	*b = (*b)[:0]
+}
+
+func (b *Behavior) peek() (ReceiveFunc, bool) {
+	if l := b.Len(); l > 0 {
+		return (*b)[l-1], true
+	}
+
+	return nil, false
+}
+
+func (b *Behavior) push(receive ReceiveFunc) {
+	*b = append(*b, receive)
+}
+
+func (b *Behavior) pop() {
+	if l := b.Len(); l > 0 {
+		(*b)[l-1] = nil
+		*b = (*b)[:l-1]
+	}
+}
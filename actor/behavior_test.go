@@ -0,0 +1,112 @@
+package actor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type becomeMessage struct{ stacked bool }
+
+type unbecomeMessage struct{}
+
+func TestBehavior_Stack(t *testing.T) {
+	var calls []string
+	record := func(name string) ReceiveFunc {
+		return func(Context) { calls = append(calls, name) }
+	}
+
+	b := NewBehavior()
+	b.Receive(nil)
+	b.Become(record("a"))
+	b.BecomeStacked(record("b"))
+	b.Receive(nil)
+	b.UnbecomeStacked()
+	b.Receive(nil)
+	b.BecomeStacked(record("c"))
+	b.Become(record("d"))
+	b.Receive(nil)
+
+	assert.Equal(t, []string{"b", "a", "d"}, calls)
+	assert.Equal(t, 1, b.Len())
+}
+
+func behaviorProps(events chan interface{}, opts ...PropsOption) *Props {
+	var angry ReceiveFunc
+	angry = func(ctx Context) {
+		switch ctx.Envelope().Message.(type) {
+		case *pingMessage:
+			events <- "angry"
+		case *unbecomeMessage:
+			ctx.UnbecomeStacked()
+		case *failMessage:
+			panic(errChildFailed)
+		}
+	}
+
+	return PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Envelope().Message.(type) {
+		case *Started:
+			events <- msg
+		case *pingMessage:
+			events <- "happy"
+		case *becomeMessage:
+			if msg.stacked {
+				ctx.BecomeStacked(angry)
+			} else {
+				ctx.Become(angry)
+			}
+		}
+	}, opts...)
+}
+
+func TestBehavior_BecomeStacked(t *testing.T) {
+	events := make(chan interface{}, 10)
+	pid := rootContext.Spawn(behaviorProps(events))
+	defer rootContext.Stop(pid)
+	assert.IsType(t, &Started{}, waitFor(t, events))
+
+	rootContext.Send(pid, WrapEnvelope(&becomeMessage{stacked: true}))
+	rootContext.Send(pid, WrapEnvelope(&pingMessage{}))
+	assert.Equal(t, "angry", waitFor(t, events))
+
+	rootContext.Send(pid, WrapEnvelope(&unbecomeMessage{}))
+	rootContext.Send(pid, WrapEnvelope(&pingMessage{}))
+	assert.Equal(t, "happy", waitFor(t, events))
+}
+
+func TestBehavior_WithReceiverMiddleware(t *testing.T) {
+	events := make(chan interface{}, 10)
+	seen := make(chan interface{}, 10)
+	middleware := func(next ReceiverFunc) ReceiverFunc {
+		return func(ctx ReceiverContext, envelope *MessageEnvelope) {
+			seen <- envelope.Message
+			next(ctx, envelope)
+		}
+	}
+
+	pid := rootContext.Spawn(behaviorProps(events, WithReceiverMiddleware(middleware)))
+	defer rootContext.Stop(pid)
+	assert.IsType(t, &Started{}, waitFor(t, events))
+
+	rootContext.Send(pid, WrapEnvelope(&becomeMessage{}))
+	rootContext.Send(pid, WrapEnvelope(&pingMessage{}))
+	assert.Equal(t, "angry", waitFor(t, events))
+	assert.IsType(t, &Started{}, waitFor(t, seen))
+	assert.IsType(t, &becomeMessage{}, waitFor(t, seen))
+	assert.IsType(t, &pingMessage{}, waitFor(t, seen))
+}
+
+func TestBehavior_ResetOnRestart(t *testing.T) {
+	events := make(chan interface{}, 10)
+	pid := rootContext.Spawn(behaviorProps(events))
+	defer rootContext.Stop(pid)
+	assert.IsType(t, &Started{}, waitFor(t, events))
+
+	rootContext.Send(pid, WrapEnvelope(&becomeMessage{}))
+	rootContext.Send(pid, WrapEnvelope(&failMessage{}))
+	assert.IsType(t, &Started{}, waitFor(t, events))
+
+	rootContext.Send(pid, WrapEnvelope(&pingMessage{}))
+	assert.Equal(t, "happy", waitFor(t, events))
+}
@@ -0,0 +1,209 @@
+package actor
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/colin1989/battery/queue/mpsc"
+)
+
+type overflowKind int
+
+const (
+	dropNewest overflowKind = iota
+	dropOldest
+	block
+	reject
+)
+
+// OverflowPolicy decides what a bounded mailbox does with a user message that arrives while it is full
+type OverflowPolicy struct {
+	kind    overflowKind
+	timeout time.Duration
+}
+
+var (
+	// DropNewest discards the incoming message
+	DropNewest = OverflowPolicy{kind: dropNewest}
+
+	// DropOldest discards the oldest queued message to make room for the incoming one
+	DropOldest = OverflowPolicy{kind: dropOldest}
+
+	// Reject refuses the incoming message, answers the sender with MailboxRejected and hands the message to the dead letters
+	Reject = OverflowPolicy{kind: reject}
+)
+
+// Block makes the sender wait up to timeout for a free slot, the message is dropped afterwards.
+// This applies backpressure to senders of a busy actor.
+// An actor must not send to itself with this policy: it is the one that would free a slot, so it stalls
+// for the whole timeout every time its mailbox is full and the message is dropped anyway.
+func Block(timeout time.Duration) OverflowPolicy {
+	return OverflowPolicy{kind: block, timeout: timeout}
+}
+
+func (p OverflowPolicy) String() string {
+	switch p.kind {
+	case dropNewest:
+		return "DropNewest"
+	case dropOldest:
+		return "DropOldest"
+	case block:
+		return "Block(" + p.timeout.String() + ")"
+	case reject:
+		return "Reject"
+	default:
+		return "Unknown"
+	}
+}
+
+// MailboxRejected is sent back to the sender when a mailbox with the Reject policy is full
+type MailboxRejected struct {
+	Target *PID
+}
+
+// MailboxOverflowEvent is published on the EventStream every time a bounded mailbox drops or rejects a message
+type MailboxOverflowEvent struct {
+	PID     *PID
+	Message interface{}
+	Sender  *PID
+	Policy  OverflowPolicy
+}
+
+var _ EventMessage = &MailboxOverflowEvent{}
+
+func (*MailboxOverflowEvent) EventMessage() {}
+
+// Bounded returns a producer which creates a mailbox holding at most size user messages.
+// System messages are never bounded.
+func Bounded(size int, policy OverflowPolicy, mailboxStats ...MailboxMiddleware) MailboxProducer {
+	return func() Mailbox {
+		q := newBoundedQueue(size)
+		return &boundedMailbox{
+			defaultMailbox: &defaultMailbox{
+				userMailbox:   q,
+				systemMailbox: mpsc.New[SystemMessage](),
+				middlewares:   mailboxStats,
+			},
+			queue:  q,
+			policy: policy,
+		}
+	}
+}
+
+// boundedQueue is a fixed capacity queue backed by a buffered channel.
+type boundedQueue struct {
+	ch chan *MessageEnvelope
+}
+
+var _ queue[*MessageEnvelope] = &boundedQueue{}
+
+func newBoundedQueue(size int) *boundedQueue {
+	if size < 1 {
+		size = 1
+	}
+
+	return &boundedQueue{ch: make(chan *MessageEnvelope, size)}
+}
+
+func (q *boundedQueue) Push(envelope *MessageEnvelope) {
+	q.ch <- envelope
+}
+
+func (q *boundedQueue) Pop() (*MessageEnvelope, bool) {
+	select {
+	case envelope := <-q.ch:
+		return envelope, true
+	default:
+		return nil, false
+	}
+}
+
+func (q *boundedQueue) offer(envelope *MessageEnvelope) bool {
+	select {
+	case q.ch <- envelope:
+		return true
+	default:
+		return false
+	}
+}
+
+func (q *boundedQueue) offerTimeout(envelope *MessageEnvelope, timeout time.Duration) bool {
+	if q.offer(envelope) {
+		return true
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case q.ch <- envelope:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+type boundedMailbox struct {
+	*defaultMailbox
+	queue  *boundedQueue
+	policy OverflowPolicy
+}
+
+// mailboxOwner is implemented by the actor context registered as the mailbox invoker.
+type mailboxOwner interface {
+	ActorSystem() *ActorSystem
+	Self() *PID
+}
+
+func (m *boundedMailbox) PostUserMessage(message *MessageEnvelope) {
+	switch m.policy.kind {
+	case dropOldest:
+		for !m.queue.offer(message) {
+			if oldest, ok := m.queue.Pop(); ok {
+				atomic.AddInt32(&m.userMessages, -1)
+				m.dropped(oldest)
+				m.overflow(oldest)
+			}
+		}
+	case block:
+		if !m.queue.offerTimeout(message, m.policy.timeout) {
+			m.overflow(message)
+			return
+		}
+	default:
+		if !m.queue.offer(message) {
+			m.overflow(message)
+			return
+		}
+	}
+
+	atomic.AddInt32(&m.userMessages, 1)
+	m.posted(message)
+	m.schedule()
+}
+
+// overflow hands a message that did not fit to the dead letter process, Reject also answers its sender.
+func (m *boundedMailbox) overflow(message *MessageEnvelope) {
+	owner, ok := m.invoker.(mailboxOwner)
+	if !ok {
+		return
+	}
+
+	system, self := owner.ActorSystem(), owner.Self()
+	_, msg, sender := UnwrapEnvelope(message)
+
+	system.EventStream.Publish(&MailboxOverflowEvent{
+		PID:     self,
+		Message: msg,
+		Sender:  sender,
+		Policy:  m.policy,
+	})
+
+	if m.policy.kind == reject && sender != nil {
+		sender.sendUserMessage(system, WrapEnvelope(&MailboxRejected{Target: self}))
+		// the sender got its answer, the dead letters must not send it a DeadLetterResponse too
+		message = &MessageEnvelope{Header: message.Header, Message: message.Message}
+	}
+
+	system.DeadLetter.SendUserMessage(self, message)
+}
@@ -0,0 +1,119 @@
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type blockMessage struct{}
+
+// spawnBlockedActor spawns an actor that is stuck in its first blockMessage until release is closed,
+// every other message is recorded in order.
+func spawnBlockedActor(t *testing.T, producer MailboxProducer) (pid *PID, release chan struct{}, received chan interface{}) {
+	release = make(chan struct{})
+	received = make(chan interface{}, 100)
+	blocked := make(chan struct{})
+	props := PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Envelope().Message.(type) {
+		case *blockMessage:
+			close(blocked)
+			<-release
+		case int:
+			received <- msg
+		}
+	}, WithMailbox(producer))
+
+	pid = rootContext.Spawn(props)
+	rootContext.Send(pid, WrapEnvelope(&blockMessage{}))
+	waitFor(t, blocked)
+
+	return pid, release, received
+}
+
+func drain(received chan interface{}, n int) []interface{} {
+	values := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		select {
+		case v := <-received:
+			values = append(values, v)
+		case <-time.After(time.Second):
+			return values
+		}
+	}
+	return values
+}
+
+func TestBoundedMailbox_DropNewest(t *testing.T) {
+	pid, release, received := spawnBlockedActor(t, Bounded(2, DropNewest))
+	defer rootContext.Stop(pid)
+
+	events := make(chan *MailboxOverflowEvent, 10)
+	sub := system.EventStream.Subscribe(func(evt EventMessage) {
+		if e, ok := evt.(*MailboxOverflowEvent); ok && e.PID.Equal(pid) {
+			events <- e
+		}
+	})
+	defer system.EventStream.Unsubscribe(sub)
+
+	for i := 1; i <= 4; i++ {
+		rootContext.Send(pid, WrapEnvelope(i))
+	}
+	close(release)
+
+	assert.Equal(t, []interface{}{1, 2}, drain(received, 2))
+	assert.Equal(t, 3, waitFor(t, events).Message)
+	assert.Equal(t, 4, waitFor(t, events).Message)
+}
+
+func TestBoundedMailbox_DropOldest(t *testing.T) {
+	pid, release, received := spawnBlockedActor(t, Bounded(2, DropOldest))
+	defer rootContext.Stop(pid)
+
+	for i := 1; i <= 4; i++ {
+		rootContext.Send(pid, WrapEnvelope(i))
+	}
+	close(release)
+
+	assert.Equal(t, []interface{}{3, 4}, drain(received, 2))
+}
+
+func TestBoundedMailbox_Reject(t *testing.T) {
+	pid, release, _ := spawnBlockedActor(t, Bounded(1, Reject))
+	defer rootContext.Stop(pid)
+	defer close(release)
+
+	deadLetters := make(chan *DeadLetterEvent, 10)
+	sub := SubscribeTo(system.EventStream, func(evt *DeadLetterEvent) {
+		deadLetters <- evt
+	}, WithPredicate(func(evt *DeadLetterEvent) bool { return evt.PID.Equal(pid) }))
+	defer system.EventStream.Unsubscribe(sub)
+
+	rootContext.Send(pid, WrapEnvelope(1))
+	_, err := rootContext.Request(pid, WrapEnvelope(2))
+	assert.Equal(t, ErrMailboxFull, err)
+
+	evt := waitFor(t, deadLetters)
+	assert.Equal(t, 2, evt.Message)
+	assert.Nil(t, evt.Sender, "the sender was answered with MailboxRejected already")
+}
+
+func TestBoundedMailbox_BlockUntilTimeout(t *testing.T) {
+	pid, release, received := spawnBlockedActor(t, Bounded(1, Block(50*time.Millisecond)))
+	defer rootContext.Stop(pid)
+
+	rootContext.Send(pid, WrapEnvelope(1))
+
+	start := time.Now()
+	rootContext.Send(pid, WrapEnvelope(2))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+	}()
+	rootContext.Send(pid, WrapEnvelope(3))
+
+	assert.Equal(t, []interface{}{1, 3}, drain(received, 2))
+}
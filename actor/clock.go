@@ -0,0 +1,30 @@
+package actor
+
+import "time"
+
+// Clock is the source of time of an ActorSystem, it drives ReceiveTimeout, futures timeouts and the Scheduler.
+// The default clock is the wall clock, testkit.VirtualClock replaces it to advance time deterministically in tests.
+type Clock interface {
+	Now() time.Time
+
+	// AfterFunc calls f in its own goroutine once d elapsed, like time.AfterFunc.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a pending call of Clock.AfterFunc, *time.Timer implements it.
+type Timer interface {
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+type realClock struct{}
+
+var _ Clock = realClock{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
@@ -0,0 +1,79 @@
+package cluster
+
+import (
+	"log/slog"
+
+	"github.com/colin1989/battery/actor"
+)
+
+// activatorName is the well-known name of the activator on every member
+const activatorName = "cluster-activator"
+
+// activator spawns the grains owned by the local member and stops them when their identity moves to another member.
+type activator struct {
+	cluster *Cluster
+	grains  map[string]*actor.PID
+}
+
+func newActivator(cluster *Cluster) actor.Producer {
+	return func() actor.Actor {
+		return &activator{
+			cluster: cluster,
+			grains:  make(map[string]*actor.PID),
+		}
+	}
+}
+
+func (a *activator) Receive(ctx actor.Context) {
+	switch msg := ctx.Envelope().Message.(type) {
+	case *ActivationRequest:
+		ctx.Respond(actor.WrapEnvelope(a.activate(ctx, msg)))
+	case *ClusterTopology:
+		a.rebalance(ctx)
+	case *actor.Terminated:
+		for key, pid := range a.grains {
+			if pid.Equal(msg.Who) {
+				delete(a.grains, key)
+				break
+			}
+		}
+	}
+}
+
+func (a *activator) activate(ctx actor.Context, msg *ActivationRequest) *ActivationResponse {
+	key := grainKey(msg.Kind, msg.Identity)
+	if pid, ok := a.grains[key]; ok {
+		return &ActivationResponse{PID: pid}
+	}
+
+	if owner, _ := a.cluster.memberList.Owner(msg.Kind, msg.Identity); owner != a.cluster.Self().Address {
+		return &ActivationResponse{Error: ErrNotOwner.Error()}
+	}
+
+	kind, ok := a.cluster.config.Kinds[msg.Kind]
+	if !ok {
+		return &ActivationResponse{Error: ErrUnknownKind.Error()}
+	}
+
+	pid, err := ctx.SpawnNamed(kind.Props, key)
+	if err != nil {
+		ctx.Logger().Error("cluster activation failed", slog.String("grain", key), slog.Any("err", err))
+		return &ActivationResponse{Error: err.Error()}
+	}
+
+	a.grains[key] = pid
+	return &ActivationResponse{PID: pid}
+}
+
+// rebalance stops the grains whose identity is now owned by another member,
+// the next Get activates them on their new owner.
+func (a *activator) rebalance(ctx actor.Context) {
+	self := a.cluster.Self().Address
+	for key, pid := range a.grains {
+		kind, identity := splitGrainKey(key)
+		if owner, _ := a.cluster.memberList.Owner(kind, identity); owner != self {
+			ctx.Stop(pid)
+			delete(a.grains, key)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/colin1989/battery/actor"
+	"github.com/colin1989/battery/actor/remote"
+)
+
+// Cluster places virtual actors (grains) on the members reported by a Provider.
+// A grain is addressed by kind and identity, it is activated on its owner member on first use
+// and activated again on another member when its owner leaves.
+type Cluster struct {
+	remote      *remote.Remote
+	actorSystem *actor.ActorSystem
+	config      *Config
+	memberList  *MemberList
+	self        *Member
+	activator   *actor.PID
+
+	mu    sync.RWMutex
+	cache map[string]*actor.PID
+}
+
+func New(r *remote.Remote, config *Config) *Cluster {
+	return &Cluster{
+		remote:      r,
+		actorSystem: r.ActorSystem(),
+		config:      config,
+		memberList:  newMemberList(),
+		cache:       make(map[string]*actor.PID),
+	}
+}
+
+// StartMember starts the remote endpoint and joins the cluster
+func (c *Cluster) StartMember() error {
+	if err := c.remote.Start(); err != nil {
+		return err
+	}
+
+	c.self = &Member{
+		Address: c.remote.Address(),
+		Kinds:   c.config.kindNames(),
+	}
+
+	activator, err := c.actorSystem.Root.SpawnNamed(actor.PropsFromProducer(newActivator(c)), activatorName)
+	if err != nil {
+		c.remote.Shutdown()
+		return err
+	}
+	c.activator = activator
+
+	if err := c.config.Provider.StartMember(c); err != nil {
+		c.actorSystem.Root.Stop(c.activator)
+		c.remote.Shutdown()
+		return err
+	}
+
+	c.Logger().Info("cluster member started", slog.String("cluster", c.config.Name), slog.String("address", c.self.Address))
+	return nil
+}
+
+// Shutdown leaves the cluster, stops the local grains and the remote endpoint
+func (c *Cluster) Shutdown() {
+	if err := c.config.Provider.Shutdown(); err != nil {
+		c.Logger().Error("cluster provider shutdown failed", slog.Any("err", err))
+	}
+
+	_ = c.actorSystem.Root.StopFuture(c.activator).Wait()
+	c.remote.Shutdown()
+	c.Logger().Info("cluster member stopped", slog.String("cluster", c.config.Name), slog.String("address", c.self.Address))
+}
+
+// Self returns the local member
+func (c *Cluster) Self() *Member {
+	return c.self
+}
+
+func (c *Cluster) ActorSystem() *actor.ActorSystem {
+	return c.actorSystem
+}
+
+func (c *Cluster) Logger() *slog.Logger {
+	return c.actorSystem.Logger()
+}
+
+// Members returns the current member list
+func (c *Cluster) Members() []*Member {
+	return c.memberList.Members()
+}
+
+// UpdateMembers is called by the provider with the full member list.
+// Cached grain PIDs whose identity moved are forgotten and the local activator hands over its grains.
+func (c *Cluster) UpdateMembers(members []*Member) {
+	topology := c.memberList.update(members)
+	if topology == nil {
+		return
+	}
+
+	c.mu.Lock()
+	for key, pid := range c.cache {
+		kind, identity := splitGrainKey(key)
+		if owner, _ := c.memberList.Owner(kind, identity); owner != pid.Address {
+			delete(c.cache, key)
+		}
+	}
+	c.mu.Unlock()
+
+	c.actorSystem.Root.Send(c.activator, actor.WrapEnvelope(topology))
+	c.actorSystem.EventStream.Publish(topology)
+}
+
+// Get returns the PID of the grain identity of kind, activating it on its owner member when needed.
+func (c *Cluster) Get(kind, identity string) (*actor.PID, error) {
+	key := grainKey(kind, identity)
+
+	c.mu.RLock()
+	pid, ok := c.cache[key]
+	c.mu.RUnlock()
+	if ok {
+		return pid, nil
+	}
+
+	var err error
+	for i := 0; i <= c.config.ActivationRetries; i++ {
+		if i > 0 {
+			time.Sleep(c.config.RetryInterval)
+		}
+
+		pid, err = c.activate(kind, identity)
+		if !errors.Is(err, ErrNotOwner) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.cache[key] = pid
+	c.mu.Unlock()
+
+	return pid, nil
+}
+
+// Request sends envelope to the grain and waits for the response.
+// When the grain is gone, e.g. its member just left, it is activated again and the request is sent once more.
+func (c *Cluster) Request(kind, identity string, envelope *actor.MessageEnvelope) (*actor.MessageEnvelope, error) {
+	pid, err := c.Get(kind, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.actorSystem.Root.RequestFuture(pid, envelope, c.config.RequestTimeout).Result()
+	if !errors.Is(err, actor.ErrDeadLetter) {
+		return res, err
+	}
+
+	c.forget(kind, identity, pid)
+	if pid, err = c.Get(kind, identity); err != nil {
+		return nil, err
+	}
+
+	return c.actorSystem.Root.RequestFuture(pid, envelope, c.config.RequestTimeout).Result()
+}
+
+func (c *Cluster) activate(kind, identity string) (*actor.PID, error) {
+	owner, ok := c.memberList.Owner(kind, identity)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoMember, kind)
+	}
+
+	res, err := c.actorSystem.Root.RequestFuture(actor.NewPID(owner, activatorName),
+		actor.WrapEnvelope(&ActivationRequest{Kind: kind, Identity: identity}), c.config.RequestTimeout).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, ok := res.Message.(*ActivationResponse)
+	if !ok {
+		return nil, fmt.Errorf("cluster: unexpected activation response %T", res.Message)
+	}
+	if resp.Error != "" {
+		return nil, activationError(resp.Error)
+	}
+
+	return resp.PID, nil
+}
+
+func (c *Cluster) forget(kind, identity string, pid *actor.PID) {
+	key := grainKey(kind, identity)
+
+	c.mu.Lock()
+	if cached, ok := c.cache[key]; ok && cached.Equal(pid) {
+		delete(c.cache, key)
+	}
+	c.mu.Unlock()
+}
+
+// activationError maps an error received from the owner back to the sentinel errors
+func activationError(s string) error {
+	for _, err := range []error{ErrNotOwner, ErrUnknownKind} {
+		if s == err.Error() {
+			return err
+		}
+	}
+
+	return errors.New(s)
+}
+
+func grainKey(kind, identity string) string {
+	return kind + "/" + identity
+}
+
+func splitGrainKey(key string) (string, string) {
+	kind, identity, _ := strings.Cut(key, "/")
+	return kind, identity
+}
@@ -0,0 +1,132 @@
+package cluster
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/colin1989/battery/actor"
+	"github.com/colin1989/battery/actor/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type increment struct{}
+
+type counted struct {
+	Count   int
+	Address string
+}
+
+func init() {
+	remote.RegisterType(&increment{}, &counted{})
+}
+
+func counterKind() *Kind {
+	return NewKind("counter", actor.PropsFromProducer(func() actor.Actor {
+		count := 0
+		return actor.ReceiveFunc(func(ctx actor.Context) {
+			if _, ok := ctx.Envelope().Message.(*increment); ok {
+				count++
+				ctx.Respond(actor.WrapEnvelope(&counted{Count: count, Address: ctx.Self().Address}))
+			}
+		})
+	}))
+}
+
+func startMember(t *testing.T, provider Provider) *Cluster {
+	t.Helper()
+	r := remote.NewRemote(actor.NewActorSystem(), remote.Configure("127.0.0.1", 0))
+	c := New(r, Configure("test", provider, WithKinds(counterKind()), WithRequestTimeout(time.Second)))
+	require.NoError(t, c.StartMember())
+
+	return c
+}
+
+func increase(t *testing.T, c *Cluster, identity string) *counted {
+	t.Helper()
+	res, err := c.Request("counter", identity, actor.WrapEnvelope(&increment{}))
+	require.NoError(t, err)
+
+	return res.Message.(*counted)
+}
+
+func TestCluster_StaticProvider(t *testing.T) {
+	c1 := startMember(t, NewStaticProvider())
+	defer c1.Shutdown()
+	c2 := startMember(t, NewStaticProvider(c1.Self()))
+	defer c2.Shutdown()
+	c1.UpdateMembers([]*Member{c1.Self(), c2.Self()})
+
+	pid1, err := c1.Get("counter", "a")
+	require.NoError(t, err)
+	pid2, err := c2.Get("counter", "a")
+	require.NoError(t, err)
+	assert.True(t, pid1.Equal(pid2))
+
+	assert.Equal(t, 1, increase(t, c1, "a").Count)
+	assert.Equal(t, 2, increase(t, c2, "a").Count)
+
+	_, err = c1.Get("missing", "a")
+	assert.ErrorIs(t, err, ErrNoMember)
+}
+
+func TestCluster_ReactivateWhenMemberLeaves(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "members.json")
+	provider := func() Provider {
+		return NewFileProvider(path, WithPollInterval(20*time.Millisecond), WithTTL(time.Second))
+	}
+
+	members := []*Cluster{startMember(t, provider()), startMember(t, provider()), startMember(t, provider())}
+	for _, c := range members {
+		c := c
+		require.Eventually(t, func() bool { return len(c.Members()) == 3 }, 2*time.Second, 10*time.Millisecond)
+	}
+
+	first := increase(t, members[0], "player-1")
+	assert.Equal(t, 1, first.Count)
+
+	var owner *Cluster
+	var survivors []*Cluster
+	for _, c := range members {
+		if c.Self().Address == first.Address {
+			owner = c
+		} else {
+			survivors = append(survivors, c)
+		}
+	}
+	require.NotNil(t, owner)
+	assert.Equal(t, 2, increase(t, survivors[0], "player-1").Count)
+
+	owner.Shutdown()
+	for _, c := range survivors {
+		c := c
+		defer c.Shutdown()
+		require.Eventually(t, func() bool { return len(c.Members()) == 2 }, 2*time.Second, 10*time.Millisecond)
+	}
+
+	moved := increase(t, survivors[1], "player-1")
+	assert.Equal(t, 1, moved.Count)
+	assert.NotEqual(t, first.Address, moved.Address)
+	assert.Equal(t, 2, increase(t, survivors[0], "player-1").Count)
+}
+
+func TestMemberList_KindsChange(t *testing.T) {
+	ml := newMemberList()
+	require.NotNil(t, ml.update([]*Member{{Address: "a", Kinds: []string{"counter"}}, {Address: "b", Kinds: []string{"counter"}}}))
+	assert.Nil(t, ml.update([]*Member{{Address: "b", Kinds: []string{"counter"}}, {Address: "a", Kinds: []string{"counter"}}}))
+
+	topology := ml.update([]*Member{{Address: "a", Kinds: []string{"counter"}}, {Address: "b", Kinds: []string{"room"}}})
+	require.NotNil(t, topology)
+	assert.Empty(t, topology.Joined)
+	assert.Empty(t, topology.Left)
+	assert.Equal(t, []*Member{{Address: "b", Kinds: []string{"room"}}}, topology.Changed)
+
+	for _, identity := range []string{"1", "2", "3", "4", "5"} {
+		owner, _ := ml.Owner("counter", identity)
+		assert.Equal(t, "a", owner)
+	}
+	owner, ok := ml.Owner("room", "1")
+	assert.True(t, ok)
+	assert.Equal(t, "b", owner)
+}
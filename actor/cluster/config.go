@@ -0,0 +1,42 @@
+package cluster
+
+import "time"
+
+type Config struct {
+	Name              string
+	Provider          Provider
+	Kinds             map[string]*Kind
+	RequestTimeout    time.Duration
+	ActivationRetries int
+	RetryInterval     time.Duration
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		Kinds:             make(map[string]*Kind),
+		RequestTimeout:    5 * time.Second,
+		ActivationRetries: 3,
+		RetryInterval:     100 * time.Millisecond,
+	}
+}
+
+// Configure creates the config of a cluster member discovering the other members through provider.
+func Configure(name string, provider Provider, options ...ConfigOption) *Config {
+	config := defaultConfig()
+	config.Name = name
+	config.Provider = provider
+	for _, option := range options {
+		option(config)
+	}
+
+	return config
+}
+
+func (c *Config) kindNames() []string {
+	names := make([]string, 0, len(c.Kinds))
+	for name := range c.Kinds {
+		names = append(names, name)
+	}
+
+	return names
+}
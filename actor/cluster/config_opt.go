@@ -0,0 +1,29 @@
+package cluster
+
+import "time"
+
+type ConfigOption func(config *Config)
+
+// WithKinds registers the grain kinds this member can host
+func WithKinds(kinds ...*Kind) ConfigOption {
+	return func(config *Config) {
+		for _, kind := range kinds {
+			config.Kinds[kind.Name] = kind
+		}
+	}
+}
+
+// WithRequestTimeout sets the timeout of activations and of Cluster.Request
+func WithRequestTimeout(timeout time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.RequestTimeout = timeout
+	}
+}
+
+// WithActivationRetries sets how many times an activation is retried while the members disagree on the owner
+func WithActivationRetries(retries int, interval time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.ActivationRetries = retries
+		config.RetryInterval = interval
+	}
+}
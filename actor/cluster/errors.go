@@ -0,0 +1,14 @@
+package cluster
+
+import "errors"
+
+var (
+	// ErrNoMember is returned when no member hosts the requested kind.
+	ErrNoMember = errors.New("cluster: no member for kind")
+
+	// ErrUnknownKind is returned when the owner member does not know the requested kind.
+	ErrUnknownKind = errors.New("cluster: unknown kind")
+
+	// ErrNotOwner is returned when the member asked for an activation does not own the identity.
+	ErrNotOwner = errors.New("cluster: member is not the owner")
+)
@@ -0,0 +1,16 @@
+package cluster
+
+import "github.com/colin1989/battery/actor"
+
+// Kind is a type of grain, each identity of a kind is activated on demand from Props.
+type Kind struct {
+	Name  string
+	Props *actor.Props
+}
+
+func NewKind(name string, props *actor.Props) *Kind {
+	return &Kind{
+		Name:  name,
+		Props: props,
+	}
+}
@@ -0,0 +1,135 @@
+package cluster
+
+import (
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/serialx/hashring"
+)
+
+// Member is a node of the cluster and the grain kinds it can host
+type Member struct {
+	Address string   `json:"address"`
+	Kinds   []string `json:"kinds"`
+}
+
+func (m *Member) HasKind(kind string) bool {
+	for _, k := range m.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MemberList is the current view of the cluster, identities are placed on a hash ring per kind.
+type MemberList struct {
+	mu      sync.RWMutex
+	members map[string]*Member
+	rings   map[string]*hashring.HashRing
+}
+
+func newMemberList() *MemberList {
+	return &MemberList{
+		members: make(map[string]*Member),
+		rings:   make(map[string]*hashring.HashRing),
+	}
+}
+
+// Members returns the members sorted by address
+func (ml *MemberList) Members() []*Member {
+	ml.mu.RLock()
+	defer ml.mu.RUnlock()
+
+	return ml.sortedMembers()
+}
+
+// Owner returns the address of the member owning identity of kind
+func (ml *MemberList) Owner(kind, identity string) (string, bool) {
+	ml.mu.RLock()
+	defer ml.mu.RUnlock()
+
+	ring, ok := ml.rings[kind]
+	if !ok {
+		return "", false
+	}
+
+	return ring.GetNode(identity)
+}
+
+// update replaces the member list, topology is nil when nothing changed.
+func (ml *MemberList) update(members []*Member) *ClusterTopology {
+	next := make(map[string]*Member, len(members))
+	for _, m := range members {
+		next[m.Address] = m
+	}
+
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	topology := &ClusterTopology{}
+	for address, m := range next {
+		prev, ok := ml.members[address]
+		switch {
+		case !ok:
+			topology.Joined = append(topology.Joined, m)
+		case !sameKinds(prev.Kinds, m.Kinds):
+			topology.Changed = append(topology.Changed, m)
+		}
+	}
+	for address, m := range ml.members {
+		if _, ok := next[address]; !ok {
+			topology.Left = append(topology.Left, m)
+		}
+	}
+	if len(topology.Joined) == 0 && len(topology.Left) == 0 && len(topology.Changed) == 0 {
+		return nil
+	}
+
+	ml.members = next
+	ml.rebuildRings()
+	topology.Members = ml.sortedMembers()
+
+	return topology
+}
+
+// sameKinds reports whether a and b hold the same kinds, in any order
+func sameKinds(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+
+	return slices.Equal(a, b)
+}
+
+func (ml *MemberList) rebuildRings() {
+	nodes := make(map[string][]string)
+	for _, m := range ml.members {
+		for _, kind := range m.Kinds {
+			nodes[kind] = append(nodes[kind], m.Address)
+		}
+	}
+
+	ml.rings = make(map[string]*hashring.HashRing, len(nodes))
+	for kind, addresses := range nodes {
+		ml.rings[kind] = hashring.New(addresses)
+	}
+}
+
+func (ml *MemberList) sortedMembers() []*Member {
+	members := make([]*Member, 0, len(ml.members))
+	for _, m := range ml.members {
+		members = append(members, m)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Address < members[j].Address
+	})
+
+	return members
+}
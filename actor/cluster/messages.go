@@ -0,0 +1,32 @@
+package cluster
+
+import (
+	"github.com/colin1989/battery/actor"
+	"github.com/colin1989/battery/actor/remote"
+)
+
+func init() {
+	remote.RegisterType(&ActivationRequest{}, &ActivationResponse{})
+}
+
+// ActivationRequest asks the activator of the owner member for the PID of a grain
+type ActivationRequest struct {
+	Kind     string
+	Identity string
+}
+
+// ActivationResponse carries the PID of the activated grain, or the reason it could not be activated
+type ActivationResponse struct {
+	PID   *actor.PID
+	Error string
+}
+
+// ClusterTopology is published on the EventStream whenever the member list changes
+type ClusterTopology struct {
+	Members []*Member
+	Joined  []*Member
+	Left    []*Member
+	Changed []*Member // members still in the cluster with other kinds than before, with their new kinds
+}
+
+func (*ClusterTopology) EventMessage() {}
@@ -0,0 +1,10 @@
+package cluster
+
+// Provider discovers the members of the cluster and reports them through Cluster.UpdateMembers.
+type Provider interface {
+	// StartMember registers the local member and starts reporting the member list
+	StartMember(cluster *Cluster) error
+
+	// Shutdown deregisters the local member
+	Shutdown() error
+}
@@ -0,0 +1,211 @@
+package cluster
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileProvider shares the member list through a JSON file, for running several members on one machine.
+// Every member refreshes its own entry each PollInterval; entries not refreshed within TTL are dropped,
+// so a crashed member eventually leaves the cluster.
+type FileProvider struct {
+	path         string
+	pollInterval time.Duration
+	ttl          time.Duration
+
+	cluster *Cluster
+	stopper chan struct{}
+	wg      sync.WaitGroup
+}
+
+var _ Provider = &FileProvider{}
+
+type FileProviderOption func(provider *FileProvider)
+
+// WithPollInterval sets how often the file is read and the local entry refreshed
+func WithPollInterval(interval time.Duration) FileProviderOption {
+	return func(provider *FileProvider) {
+		provider.pollInterval = interval
+	}
+}
+
+// WithTTL sets how long an entry stays valid without being refreshed
+func WithTTL(ttl time.Duration) FileProviderOption {
+	return func(provider *FileProvider) {
+		provider.ttl = ttl
+	}
+}
+
+type fileEntry struct {
+	Member
+	UpdatedAt int64 `json:"updatedAt"`
+}
+
+func NewFileProvider(path string, options ...FileProviderOption) *FileProvider {
+	p := &FileProvider{
+		path:         path,
+		pollInterval: time.Second,
+		ttl:          5 * time.Second,
+	}
+	for _, option := range options {
+		option(p)
+	}
+
+	return p
+}
+
+func (p *FileProvider) StartMember(cluster *Cluster) error {
+	p.cluster = cluster
+	p.stopper = make(chan struct{})
+	if err := p.refresh(false); err != nil {
+		return err
+	}
+
+	p.wg.Add(1)
+	go p.poll()
+	return nil
+}
+
+func (p *FileProvider) Shutdown() error {
+	if p.stopper == nil {
+		return nil
+	}
+
+	close(p.stopper)
+	p.wg.Wait()
+	return p.refresh(true)
+}
+
+func (p *FileProvider) poll() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stopper:
+			return
+		case <-ticker.C:
+			if err := p.refresh(false); err != nil {
+				p.cluster.Logger().Error("cluster file provider refresh failed", slog.Any("err", err))
+			}
+		}
+	}
+}
+
+// refresh writes the local entry, or removes it when leaving, and reports the live members.
+func (p *FileProvider) refresh(leaving bool) error {
+	unlock, err := p.lock()
+	if err != nil {
+		return err
+	}
+
+	entries, err := p.read()
+	if err != nil {
+		unlock()
+		return err
+	}
+
+	self := p.cluster.Self()
+	now := time.Now()
+	live := make([]*fileEntry, 0, len(entries)+1)
+	for _, e := range entries {
+		if e.Address == self.Address || now.Sub(time.UnixMilli(e.UpdatedAt)) > p.ttl {
+			continue
+		}
+		live = append(live, e)
+	}
+	if !leaving {
+		live = append(live, &fileEntry{Member: *self, UpdatedAt: now.UnixMilli()})
+	}
+
+	err = p.write(live)
+	unlock()
+	if err != nil || leaving {
+		return err
+	}
+
+	members := make([]*Member, 0, len(live))
+	for _, e := range live {
+		m := e.Member
+		members = append(members, &m)
+	}
+	p.cluster.UpdateMembers(members)
+	return nil
+}
+
+func (p *FileProvider) read() ([]*fileEntry, error) {
+	data, err := os.ReadFile(p.path)
+	if errors.Is(err, os.ErrNotExist) || len(data) == 0 {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*fileEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("cluster: invalid member file %s: %w", p.path, err)
+	}
+
+	return entries, nil
+}
+
+// write replaces the file atomically so readers never see a partial member list.
+func (p *FileProvider) write(entries []*fileEntry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p.path), filepath.Base(p.path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), p.path)
+}
+
+// lock guards the read-modify-write of the member file against the other members with a lock file.
+// A lock file older than the TTL is left over by a crashed member and is taken over.
+func (p *FileProvider) lock() (func(), error) {
+	path := p.path + ".lock"
+	deadline := time.Now().Add(p.ttl)
+
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > p.ttl {
+			_ = os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("cluster: member file %s is locked", p.path)
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,31 @@
+package cluster
+
+// StaticProvider is a fixed member list, e.g. from the server config.
+// Members never leave, the local member is added when it is missing from the list.
+type StaticProvider struct {
+	members []*Member
+}
+
+var _ Provider = &StaticProvider{}
+
+func NewStaticProvider(members ...*Member) *StaticProvider {
+	return &StaticProvider{members: members}
+}
+
+func (p *StaticProvider) StartMember(cluster *Cluster) error {
+	self := cluster.Self()
+	members := append([]*Member{self}, p.members...)
+	for _, m := range p.members {
+		if m.Address == self.Address {
+			members = p.members
+			break
+		}
+	}
+
+	cluster.UpdateMembers(members)
+	return nil
+}
+
+func (p *StaticProvider) Shutdown() error {
+	return nil
+}
@@ -3,6 +3,7 @@ package actor
 import (
 	"log/slog"
 	"os"
+	"reflect"
 	"time"
 
 	"github.com/lmittmann/tint"
@@ -10,6 +11,20 @@ import (
 
 type Config struct {
 	LoggerFactory func(system *ActorSystem) *slog.Logger
+	Clock         Clock
+
+	// DefaultRequestTimeout bounds Request when no WithRequestTimeout is given
+	DefaultRequestTimeout time.Duration
+	// DefaultStopTimeout bounds the futures of StopFuture and PoisonFuture
+	DefaultStopTimeout time.Duration
+
+	// DeadLetterThrottleCount dead letters are logged per DeadLetterThrottleInterval, 0 disables the logs
+	DeadLetterThrottleInterval time.Duration
+	DeadLetterThrottleCount    int
+	// DeadLetterBufferSize is the number of recent dead letters kept, see deadLetter.Recent
+	DeadLetterBufferSize int
+	// DeadLetterSuppressed message types are neither logged, counted nor kept
+	DeadLetterSuppressed []reflect.Type
 }
 
 func defaultConfig() *Config {
@@ -23,5 +38,33 @@ func defaultConfig() *Config {
 				TimeFormat: time.Kitchen,
 			})).With("lib", "Proto.Actor").
 				With("system", system.ID)
-		}}
+		},
+		Clock:                      realClock{},
+		DefaultRequestTimeout:      5 * time.Second,
+		DefaultStopTimeout:         10 * time.Second,
+		DeadLetterThrottleInterval: time.Second,
+		DeadLetterThrottleCount:    10,
+		DeadLetterBufferSize:       100,
+	}
+}
+
+// withDefaults returns a copy of config, the fields a caller-built Config left unset are taken from defaultConfig.
+// The dead letter settings are kept as they are, 0 is meaningful for them.
+func (config *Config) withDefaults() *Config {
+	c := *config
+	defaults := defaultConfig()
+	if c.LoggerFactory == nil {
+		c.LoggerFactory = defaults.LoggerFactory
+	}
+	if c.Clock == nil {
+		c.Clock = defaults.Clock
+	}
+	if c.DefaultRequestTimeout <= 0 {
+		c.DefaultRequestTimeout = defaults.DefaultRequestTimeout
+	}
+	if c.DefaultStopTimeout <= 0 {
+		c.DefaultStopTimeout = defaults.DefaultStopTimeout
+	}
+
+	return &c
 }
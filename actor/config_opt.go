@@ -1,6 +1,10 @@
 package actor
 
-import "log/slog"
+import (
+	"log/slog"
+	"reflect"
+	"time"
+)
 
 type ConfigOption func(config *Config)
 
@@ -19,3 +23,48 @@ func WithLoggerFactory(factory func(system *ActorSystem) *slog.Logger) ConfigOpt
 		config.LoggerFactory = factory
 	}
 }
+
+// WithClock sets the clock driving the timers of the actor system
+func WithClock(clock Clock) ConfigOption {
+	return func(config *Config) {
+		config.Clock = clock
+	}
+}
+
+// WithDeadLetterThrottling logs at most count dead letters per interval, a count of 0 disables the logs
+func WithDeadLetterThrottling(count int, interval time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.DeadLetterThrottleCount = count
+		config.DeadLetterThrottleInterval = interval
+	}
+}
+
+// WithDeadLetterBufferSize sets the number of recent dead letters kept
+func WithDeadLetterBufferSize(size int) ConfigOption {
+	return func(config *Config) {
+		config.DeadLetterBufferSize = size
+	}
+}
+
+// WithDeadLetterSuppression ignores the dead letters of the types of messages, e.g. &Terminated{}, &PoisonPill{}
+func WithDeadLetterSuppression(messages ...interface{}) ConfigOption {
+	return func(config *Config) {
+		for _, msg := range messages {
+			config.DeadLetterSuppressed = append(config.DeadLetterSuppressed, reflect.TypeOf(msg))
+		}
+	}
+}
+
+// WithDefaultRequestTimeout sets how long Request waits when no WithRequestTimeout is given
+func WithDefaultRequestTimeout(timeout time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.DefaultRequestTimeout = timeout
+	}
+}
+
+// WithDefaultStopTimeout sets how long the futures of StopFuture and PoisonFuture wait for the actor to stop
+func WithDefaultStopTimeout(timeout time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.DefaultStopTimeout = timeout
+	}
+}
@@ -60,6 +60,18 @@ type basePart interface {
 
 	CancelReceiveTimeout()
 
+	// Scheduler returns the scheduler owned by the actor, its timers are cancelled when the actor stops
+	Scheduler() *Scheduler
+
+	// Become replaces the message handler of the actor until it restarts
+	Become(receive ReceiveFunc)
+
+	// BecomeStacked pushes a message handler, UnbecomeStacked returns to the previous one
+	BecomeStacked(receive ReceiveFunc)
+
+	// UnbecomeStacked pops the handler pushed by BecomeStacked, the actor Receive is used once the stack is empty
+	UnbecomeStacked()
+
 	// Children returns a slice of the actors children
 	Children() []*PID
 
@@ -67,14 +79,24 @@ type basePart interface {
 	// If the Sender is nil, the actor will panic
 	Respond(envelope *MessageEnvelope)
 
-	// Stash stashes the current message on a stack for reprocessing when the actor restarts
-	Stash()
+	// Stash keeps the current message aside until UnstashAll, or until the actor restarts.
+	// It returns ErrStashFull when the stash reached the capacity set by WithStashCapacity.
+	Stash() error
+
+	// UnstashAll replays the stashed messages in the order they were stashed, once the current message is processed
+	// and before the next message of the mailbox.
+	UnstashAll()
 
 	// Watch registers the actor as a monitor for the specified PID
 	Watch(pid *PID)
 
 	// Unwatch unregisters the actor as a monitor for the specified PID
 	Unwatch(pid *PID)
+
+	// ReenterAfter runs continuation on the actor goroutine once future completes, without blocking the actor.
+	// The message being processed when ReenterAfter was called is restored while the continuation runs,
+	// so Sender and Respond keep working.
+	ReenterAfter(future *Future, continuation func(res *MessageEnvelope, err error), opts ...ReenterOption)
 }
 
 type messagePart interface {
@@ -92,8 +114,12 @@ type senderPart interface {
 	// Send sends a message to the given PID
 	Send(pid *PID, envelope *MessageEnvelope)
 
-	// Request sends a message to a given PID and returns a Future
-	Request(pid *PID, envelope *MessageEnvelope) (*MessageEnvelope, error)
+	// Request sends a message to a given PID and waits for the response,
+	// at most Config.DefaultRequestTimeout unless WithRequestTimeout is given.
+	Request(pid *PID, envelope *MessageEnvelope, opts ...RequestOption) (*MessageEnvelope, error)
+
+	// RequestFuture sends a message to a given PID and returns a Future without blocking
+	RequestFuture(pid *PID, envelope *MessageEnvelope, timeout time.Duration) *Future
 }
 
 type receiverPart interface {
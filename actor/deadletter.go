@@ -3,6 +3,7 @@ package actor
 type deadLetter struct {
 	pid         *PID
 	actorSystem *ActorSystem
+	monitor     *deadLetterMonitor
 }
 
 // DeadLetterEvent
@@ -20,33 +21,31 @@ func (d *DeadLetterEvent) EventMessage() {}
 func newDeadLetter(actorSystem *ActorSystem) *deadLetter {
 	dl := &deadLetter{
 		actorSystem: actorSystem,
+		monitor:     newDeadLetterMonitor(actorSystem),
 	}
 	dl.pid, _ = actorSystem.ProcessRegistry.Add(dl, "deadLetter")
 
+	// log, count and keep the dead letters, including the ones published by remote
+	SubscribeTo(actorSystem.EventStream, dl.monitor.record)
+
 	// subscribe DeadLetterEvent
-	actorSystem.EventStream.Subscribe(func(msg EventMessage) {
-		dlEvent, ok := msg.(*DeadLetterEvent)
-		if !ok {
-			return
-		}
+	SubscribeTo(actorSystem.EventStream, func(dlEvent *DeadLetterEvent) {
 		// send back a response instead of timeout.
-		if dlEvent.Sender != nil {
-			actorSystem.Root.Send(dlEvent.Sender, WrapEnvelope(&DeadLetterResponse{Target: dlEvent.PID}))
-		}
-	})
+		actorSystem.Root.Send(dlEvent.Sender, WrapEnvelope(&DeadLetterResponse{Target: dlEvent.PID}))
+	}, WithPredicate(func(dlEvent *DeadLetterEvent) bool {
+		return dlEvent.Sender != nil
+	}))
 
 	// this subscriber may not be deactivated.
 	// it ensures that Watch commands that reach a stopped actor gets a Terminated message back.
 	// This can happen if one actor tries to Watch a PID, while another thread sends a Stop message.
-	actorSystem.EventStream.Subscribe(func(msg EventMessage) {
-		if dlEvent, ok := msg.(*DeadLetterEvent); ok {
-			if m, ok := dlEvent.Message.(*Watch); ok {
-				// we know that this is a local actor since we get it on our own event stream, thus the address is not terminated
-				m.Watcher.sendSystemMessage(actorSystem, &Terminated{
-					Who: dlEvent.PID,
-					Why: TerminatedReason_NotFound,
-				})
-			}
+	SubscribeTo(actorSystem.EventStream, func(dlEvent *DeadLetterEvent) {
+		if m, ok := dlEvent.Message.(*Watch); ok {
+			// we know that this is a local actor since we get it on our own event stream, thus the address is not terminated
+			m.Watcher.sendSystemMessage(actorSystem, &Terminated{
+				Who: dlEvent.PID,
+				Why: TerminatedReason_NotFound,
+			})
 		}
 	})
 
@@ -0,0 +1,214 @@
+package actor
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// OtherDeadLetterTargets is the counter of the targets seen once maxDeadLetterTargets targets are counted
+const OtherDeadLetterTargets = "*"
+
+// maxDeadLetterTargets bounds the counters, dead agents and futures would make them grow forever
+const maxDeadLetterTargets = 1024
+
+// routedMessage is implemented by the messages addressed to a route, e.g. *message.Message
+type routedMessage interface {
+	RouteName() string
+}
+
+// DeadLetterRecord is a dead letter kept by the monitor, see deadLetter.Recent.
+type DeadLetterRecord struct {
+	At          time.Time
+	PID         *PID
+	Sender      *PID
+	MessageType string
+	Route       string
+	Message     interface{}
+}
+
+// deadLetterMonitor logs, counts and keeps the latest dead letters of the actor system
+type deadLetterMonitor struct {
+	actorSystem *ActorSystem
+	suppressed  map[reflect.Type]struct{}
+
+	mu          sync.Mutex
+	counters    map[string]uint64
+	total       uint64
+	recent      []DeadLetterRecord
+	next        int
+	windowStart time.Time
+	logged      int
+	throttled   int
+	flushTimer  Timer
+	flushing    bool // flushTimer is armed
+}
+
+func newDeadLetterMonitor(actorSystem *ActorSystem) *deadLetterMonitor {
+	config := actorSystem.Config
+	m := &deadLetterMonitor{
+		actorSystem: actorSystem,
+		suppressed:  make(map[reflect.Type]struct{}, len(config.DeadLetterSuppressed)),
+		counters:    make(map[string]uint64),
+		recent:      make([]DeadLetterRecord, 0, max(config.DeadLetterBufferSize, 0)),
+	}
+	for _, typ := range config.DeadLetterSuppressed {
+		m.suppressed[typ] = struct{}{}
+	}
+
+	return m
+}
+
+func (m *deadLetterMonitor) record(evt *DeadLetterEvent) {
+	if _, ok := m.suppressed[reflect.TypeOf(evt.Message)]; ok {
+		return
+	}
+
+	rec := DeadLetterRecord{
+		At:          m.actorSystem.Config.Clock.Now(),
+		PID:         evt.PID,
+		Sender:      evt.Sender,
+		MessageType: fmt.Sprintf("%T", evt.Message),
+		Message:     evt.Message,
+	}
+	if routed, ok := evt.Message.(routedMessage); ok {
+		rec.Route = routed.RouteName()
+	}
+
+	m.mu.Lock()
+	m.count(rec.PID)
+	m.push(rec)
+	log, throttled := m.throttle(rec.At)
+	m.mu.Unlock()
+
+	m.logThrottled(throttled)
+	if log {
+		m.actorSystem.Logger().Info("dead letter",
+			slog.Any("target", rec.PID),
+			slog.String("type", rec.MessageType),
+			slog.String("route", rec.Route),
+			slog.Any("sender", rec.Sender))
+	}
+}
+
+func (m *deadLetterMonitor) count(pid *PID) {
+	m.total++
+
+	key := OtherDeadLetterTargets
+	if pid != nil {
+		key = pid.String()
+	}
+	if _, ok := m.counters[key]; !ok && len(m.counters) >= maxDeadLetterTargets {
+		key = OtherDeadLetterTargets
+	}
+	m.counters[key]++
+}
+
+func (m *deadLetterMonitor) push(rec DeadLetterRecord) {
+	size := cap(m.recent)
+	if size == 0 {
+		return
+	}
+
+	if len(m.recent) < size {
+		m.recent = append(m.recent, rec)
+	} else {
+		m.recent[m.next] = rec
+	}
+	m.next = (m.next + 1) % size
+}
+
+// throttle allows DeadLetterThrottleCount logs per DeadLetterThrottleInterval,
+// it returns how many were throttled during the previous interval when a new one begins.
+// The first throttled letter of an interval arms a Config.Clock timer flushing the count when the interval ends,
+// so it is logged even if no dead letter follows.
+func (m *deadLetterMonitor) throttle(now time.Time) (bool, int) {
+	config := m.actorSystem.Config
+	if config.DeadLetterThrottleCount <= 0 {
+		return false, 0
+	}
+
+	throttled := 0
+	if now.Sub(m.windowStart) >= config.DeadLetterThrottleInterval {
+		throttled = m.throttled
+		m.windowStart = now
+		m.logged = 0
+		m.throttled = 0
+		if m.flushing && m.flushTimer.Stop() {
+			m.flushing = false
+		}
+	}
+
+	if m.logged >= config.DeadLetterThrottleCount {
+		m.throttled++
+		if !m.flushing {
+			m.flushing = true
+			d := m.windowStart.Add(config.DeadLetterThrottleInterval).Sub(now)
+			if m.flushTimer == nil {
+				m.flushTimer = config.Clock.AfterFunc(d, m.flush)
+			} else {
+				m.flushTimer.Reset(d)
+			}
+		}
+		return false, throttled
+	}
+	m.logged++
+
+	return true, throttled
+}
+
+// flush logs the letters throttled so far, it runs when the throttling interval ends
+func (m *deadLetterMonitor) flush() {
+	m.mu.Lock()
+	throttled := m.throttled
+	m.throttled = 0
+	m.flushing = false
+	m.mu.Unlock()
+
+	m.logThrottled(throttled)
+}
+
+func (m *deadLetterMonitor) logThrottled(throttled int) {
+	if throttled > 0 {
+		m.actorSystem.Logger().Warn("dead letters throttled", slog.Int("count", throttled),
+			slog.Duration("interval", m.actorSystem.Config.DeadLetterThrottleInterval))
+	}
+}
+
+// Total returns the number of dead letters, suppressed messages excluded.
+func (dp *deadLetter) Total() uint64 {
+	dp.monitor.mu.Lock()
+	defer dp.monitor.mu.Unlock()
+
+	return dp.monitor.total
+}
+
+// Counts returns the number of dead letters by target PID, see OtherDeadLetterTargets.
+func (dp *deadLetter) Counts() map[string]uint64 {
+	dp.monitor.mu.Lock()
+	defer dp.monitor.mu.Unlock()
+
+	counts := make(map[string]uint64, len(dp.monitor.counters))
+	for k, v := range dp.monitor.counters {
+		counts[k] = v
+	}
+
+	return counts
+}
+
+// Recent returns the latest dead letters, oldest first, at most Config.DeadLetterBufferSize.
+func (dp *deadLetter) Recent() []DeadLetterRecord {
+	dp.monitor.mu.Lock()
+	defer dp.monitor.mu.Unlock()
+
+	recent := dp.monitor.recent
+	records := make([]DeadLetterRecord, 0, len(recent))
+	if len(recent) < cap(recent) {
+		return append(records, recent...)
+	}
+
+	records = append(records, recent[dp.monitor.next:]...)
+	return append(records, recent[:dp.monitor.next]...)
+}
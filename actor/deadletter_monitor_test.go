@@ -0,0 +1,107 @@
+package actor
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// manualClock only moves Now, its timers run on the wall clock
+type manualClock struct {
+	realClock
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) add(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+func TestDeadLetterMonitor(t *testing.T) {
+	var logs bytes.Buffer
+	clock := &manualClock{now: time.Unix(0, 0)}
+	as := NewActorSystem(
+		WithClock(clock),
+		WithLoggerFactory(func(*ActorSystem) *slog.Logger {
+			return slog.New(slog.NewTextHandler(&logs, nil))
+		}),
+		WithDeadLetterThrottling(2, time.Second),
+		WithDeadLetterBufferSize(3),
+		WithDeadLetterSuppression(&PoisonPill{}),
+	)
+
+	missing := as.NewLocalPID("missing")
+	for i := 1; i <= 5; i++ {
+		as.Root.Send(missing, WrapEnvelope(&tickMessage{n: i}))
+	}
+	as.Root.Poison(missing)
+
+	assert.Equal(t, uint64(5), as.DeadLetter.Total())
+	assert.Equal(t, map[string]uint64{missing.String(): 5}, as.DeadLetter.Counts())
+
+	recent := as.DeadLetter.Recent()
+	require.Len(t, recent, 3)
+	for i, rec := range recent {
+		assert.Equal(t, &tickMessage{n: i + 3}, rec.Message)
+		assert.Equal(t, "*actor.tickMessage", rec.MessageType)
+		assert.Equal(t, missing, rec.PID)
+	}
+	assert.Equal(t, 2, strings.Count(logs.String(), `msg="dead letter"`))
+
+	// the next interval reports the throttled letters
+	clock.add(time.Second)
+	as.Root.Send(missing, WrapEnvelope(&tickMessage{n: 6}))
+	assert.Contains(t, logs.String(), `msg="dead letters throttled" count=3`)
+	assert.Equal(t, 3, strings.Count(logs.String(), `msg="dead letter"`))
+}
+
+func TestDeadLetterMonitor_CountersAreBounded(t *testing.T) {
+	as := NewActorSystem(WithDeadLetterThrottling(0, time.Second))
+
+	for i := 0; i < maxDeadLetterTargets+10; i++ {
+		as.Root.Send(as.NewLocalPID("missing"+as.ProcessRegistry.NextId()), WrapEnvelope(&tickMessage{n: i}))
+	}
+
+	counts := as.DeadLetter.Counts()
+	assert.Len(t, counts, maxDeadLetterTargets+1)
+	assert.Equal(t, uint64(10), counts[OtherDeadLetterTargets])
+}
+
+func TestDeadLetterMonitor_FlushesThrottledWhenIntervalEnds(t *testing.T) {
+	var logs bytes.Buffer
+	clock := &stepClock{now: time.Unix(0, 0)}
+	as := NewActorSystem(
+		WithClock(clock),
+		WithLoggerFactory(func(*ActorSystem) *slog.Logger {
+			return slog.New(slog.NewTextHandler(&logs, nil))
+		}),
+		WithDeadLetterThrottling(2, time.Second),
+	)
+
+	missing := as.NewLocalPID("missing")
+	for i := 1; i <= 5; i++ {
+		as.Root.Send(missing, WrapEnvelope(&tickMessage{n: i}))
+	}
+	assert.NotContains(t, logs.String(), "dead letters throttled")
+
+	// no dead letter follows, the timer of the interval reports the throttled ones
+	clock.fire(1)
+	assert.Contains(t, logs.String(), `msg="dead letters throttled" count=3`)
+
+	clock.fire(1)
+	assert.Equal(t, 1, strings.Count(logs.String(), "dead letters throttled"))
+}
@@ -1,5 +1,10 @@
 package actor
 
+import (
+	"sync"
+	"sync/atomic"
+)
+
 type Dispatcher interface {
 	Schedule(fn func())
 	Throughput() int
@@ -36,3 +41,251 @@ func (d synchronizedDispatcher) Throughput() int {
 func NewSynchronizedDispatcher(throughput int) Dispatcher {
 	return synchronizedDispatcher(throughput)
 }
+
+type workerPoolConfig struct {
+	queueSize int
+	spawn     bool
+}
+
+// WorkerPoolOption configures NewWorkerPoolDispatcher
+type WorkerPoolOption func(config *workerPoolConfig)
+
+// WithWorkerPoolQueueSize sets how many mailboxes can wait for a worker, 1024 per worker by default
+func WithWorkerPoolQueueSize(size int) WorkerPoolOption {
+	return func(config *workerPoolConfig) {
+		config.queueSize = size
+	}
+}
+
+// WithWorkerPoolOverflowGoroutine runs a mailbox on a new goroutine when the queue is full, instead of keeping it in
+// the overflow list of the pool. The overflow then runs at once, at the cost of a goroutine per overflow.
+func WithWorkerPoolOverflowGoroutine() WorkerPoolOption {
+	return func(config *workerPoolConfig) {
+		config.spawn = true
+	}
+}
+
+// WorkerPoolDispatcher runs mailboxes on a fixed number of goroutines instead of one goroutine per schedule.
+type WorkerPoolDispatcher struct {
+	throughput int
+	spawn      bool
+	tasks      chan func()
+	wake       chan struct{}
+	overflows  uint64
+	workers    sync.WaitGroup
+
+	overflowMu sync.Mutex
+	overflow   []func()
+
+	mu      sync.RWMutex
+	stopped bool
+}
+
+var _ Dispatcher = &WorkerPoolDispatcher{}
+
+// NewWorkerPoolDispatcher starts workers goroutines running the scheduled mailboxes.
+// A mailbox occupies a worker until it is empty, so actors blocking in Request can starve the pool.
+// Schedule never blocks: when the queue is full, the mailbox waits in an unbounded overflow list the workers drain,
+// see WithWorkerPoolOverflowGoroutine. Workers schedule mailboxes themselves when actors send, so blocking on the full
+// queue could deadlock the pool.
+// Call Stop once the actors using it stopped to end the workers.
+func NewWorkerPoolDispatcher(workers, throughput int, opts ...WorkerPoolOption) *WorkerPoolDispatcher {
+	config := &workerPoolConfig{queueSize: workers * 1024}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	d := &WorkerPoolDispatcher{
+		throughput: throughput,
+		spawn:      config.spawn,
+		tasks:      make(chan func(), config.queueSize),
+		wake:       make(chan struct{}, 1),
+	}
+	d.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go d.work()
+	}
+
+	return d
+}
+
+func (d *WorkerPoolDispatcher) work() {
+	defer d.workers.Done()
+	for {
+		if fn, ok := d.popOverflow(); ok {
+			fn()
+			continue
+		}
+
+		select {
+		case fn, ok := <-d.tasks:
+			if !ok {
+				d.drainOverflow()
+				return
+			}
+			fn()
+		case <-d.wake:
+		}
+	}
+}
+
+func (d *WorkerPoolDispatcher) pushOverflow(fn func()) {
+	d.overflowMu.Lock()
+	d.overflow = append(d.overflow, fn)
+	d.overflowMu.Unlock()
+
+	// the pending wake up covers this push if one is queued already
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (d *WorkerPoolDispatcher) popOverflow() (func(), bool) {
+	d.overflowMu.Lock()
+	defer d.overflowMu.Unlock()
+
+	if len(d.overflow) == 0 {
+		return nil, false
+	}
+	fn := d.overflow[0]
+	d.overflow[0] = nil
+	d.overflow = d.overflow[1:]
+
+	return fn, true
+}
+
+func (d *WorkerPoolDispatcher) drainOverflow() {
+	for {
+		fn, ok := d.popOverflow()
+		if !ok {
+			return
+		}
+		fn()
+	}
+}
+
+func (d *WorkerPoolDispatcher) Schedule(fn func()) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.stopped {
+		// the actors still using a stopped pool keep running
+		go fn()
+		return
+	}
+
+	select {
+	case d.tasks <- fn:
+		return
+	default:
+	}
+
+	atomic.AddUint64(&d.overflows, 1)
+	if d.spawn {
+		go fn()
+		return
+	}
+	d.pushOverflow(fn)
+}
+
+func (d *WorkerPoolDispatcher) Throughput() int {
+	return d.throughput
+}
+
+// Overflows returns how many times Schedule found the queue full
+func (d *WorkerPoolDispatcher) Overflows() uint64 {
+	return atomic.LoadUint64(&d.overflows)
+}
+
+// Stop ends the workers once they ran the mailboxes already scheduled, later schedules run on their own goroutine.
+func (d *WorkerPoolDispatcher) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopped {
+		return
+	}
+	d.stopped = true
+	close(d.tasks)
+}
+
+// pinnedDispatcher is a template, every actor spawned with it gets its own pinnedWorker.
+type pinnedDispatcher int
+
+var _ Dispatcher = pinnedDispatcher(0)
+
+// NewPinnedDispatcher runs each actor spawned with it on a dedicated goroutine, e.g. a room tick loop.
+// The goroutine exits when the actor stops.
+func NewPinnedDispatcher(throughput int) Dispatcher {
+	return pinnedDispatcher(throughput)
+}
+
+func (d pinnedDispatcher) Schedule(fn func()) {
+	go fn()
+}
+
+func (d pinnedDispatcher) Throughput() int {
+	return int(d)
+}
+
+func (d pinnedDispatcher) pin() *pinnedWorker {
+	w := &pinnedWorker{
+		throughput: int(d),
+		tasks:      make(chan func(), 1),
+		done:       make(chan struct{}),
+	}
+	go w.work()
+
+	return w
+}
+
+type pinnedWorker struct {
+	throughput int
+	tasks      chan func()
+	done       chan struct{}
+	stopped    int32
+}
+
+var _ Dispatcher = &pinnedWorker{}
+
+func (w *pinnedWorker) work() {
+	for {
+		select {
+		case fn := <-w.tasks:
+			fn()
+		case <-w.done:
+			// run what was scheduled while the actor stopped, it drops the remaining messages
+			select {
+			case fn := <-w.tasks:
+				fn()
+			default:
+			}
+			return
+		}
+	}
+}
+
+// Schedule never blocks, the mailbox only schedules again once the previous run ended
+func (w *pinnedWorker) Schedule(fn func()) {
+	if atomic.LoadInt32(&w.stopped) == 1 {
+		go fn()
+		return
+	}
+
+	select {
+	case w.tasks <- fn:
+	default:
+		go fn()
+	}
+}
+
+func (w *pinnedWorker) Throughput() int {
+	return w.throughput
+}
+
+func (w *pinnedWorker) stop() {
+	if atomic.CompareAndSwapInt32(&w.stopped, 0, 1) {
+		close(w.done)
+	}
+}
@@ -0,0 +1,185 @@
+package actor
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPoolDispatcher_ProcessesInOrder(t *testing.T) {
+	dispatcher := NewWorkerPoolDispatcher(2, 300)
+	defer dispatcher.Stop()
+	events := make(chan interface{}, 100)
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Envelope().Message.(*tickMessage); ok {
+			events <- msg.n
+		}
+	}, WithDispatcher(dispatcher)))
+	defer rootContext.Stop(pid)
+
+	for i := 0; i < 50; i++ {
+		rootContext.Send(pid, WrapEnvelope(&tickMessage{n: i}))
+	}
+	for i := 0; i < 50; i++ {
+		assert.Equal(t, i, waitFor(t, events))
+	}
+}
+
+func TestWorkerPoolDispatcher_Overflow(t *testing.T) {
+	for _, spawn := range []bool{false, true} {
+		var opts []WorkerPoolOption
+		if spawn {
+			opts = append(opts, WithWorkerPoolOverflowGoroutine())
+		}
+		d := NewWorkerPoolDispatcher(1, 300, append(opts, WithWorkerPoolQueueSize(1))...)
+
+		release, ran := make(chan struct{}), make(chan int, 3)
+		d.Schedule(func() { <-release; ran <- 1 })
+		assert.Eventually(t, func() bool { return len(d.tasks) == 0 }, time.Second, time.Millisecond)
+		d.Schedule(func() { ran <- 2 })
+
+		// Schedule never blocks on the full queue
+		d.Schedule(func() { ran <- 3 })
+
+		if spawn {
+			assert.Equal(t, 3, waitFor(t, ran), "the overflow should run on its own goroutine")
+		} else {
+			select {
+			case n := <-ran:
+				t.Fatalf("%d ran while the worker was busy", n)
+			case <-time.After(20 * time.Millisecond):
+			}
+		}
+		close(release)
+		for len(ran) < 2 {
+			time.Sleep(time.Millisecond)
+		}
+		assert.Equal(t, uint64(1), d.Overflows())
+		d.Stop()
+	}
+}
+
+func TestWorkerPoolDispatcher_WorkersScheduleOnFullQueue(t *testing.T) {
+	d := NewWorkerPoolDispatcher(2, 300, WithWorkerPoolQueueSize(4))
+	defer d.Stop()
+
+	// like actors sending from a worker, both workers schedule more than the queue holds
+	const fanOut = 100
+	var wg sync.WaitGroup
+	wg.Add(2 * fanOut)
+	for i := 0; i < 2; i++ {
+		d.Schedule(func() {
+			for j := 0; j < fanOut; j++ {
+				d.Schedule(wg.Done)
+			}
+		})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	waitFor(t, done)
+	assert.NotZero(t, d.Overflows())
+}
+
+func TestWorkerPoolDispatcher_Stop(t *testing.T) {
+	d := NewWorkerPoolDispatcher(8, 300)
+	d.Stop()
+	d.Stop()
+
+	exited := make(chan struct{})
+	go func() {
+		d.workers.Wait()
+		close(exited)
+	}()
+	waitFor(t, exited)
+
+	ran := make(chan struct{})
+	d.Schedule(func() { close(ran) })
+	waitFor(t, ran)
+}
+
+func TestPinnedDispatcher_StopsWithActor(t *testing.T) {
+	events := make(chan interface{}, 10)
+	props := PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Envelope().Message.(*tickMessage); ok {
+			events <- msg.n
+		}
+	}, WithDispatcher(NewPinnedDispatcher(300)))
+
+	pid1 := rootContext.Spawn(props)
+	pid2 := rootContext.Spawn(props)
+	w1 := pinnedWorkerOf(pid1)
+	w2 := pinnedWorkerOf(pid2)
+	assert.NotSame(t, w1, w2)
+
+	rootContext.Send(pid1, WrapEnvelope(&tickMessage{n: 1}))
+	assert.Equal(t, 1, waitFor(t, events))
+
+	assert.NoError(t, rootContext.StopFuture(pid1).Wait())
+	waitFor(t, (<-chan struct{})(w1.done))
+
+	rootContext.Send(pid2, WrapEnvelope(&tickMessage{n: 2}))
+	assert.Equal(t, 2, waitFor(t, events))
+	assert.NoError(t, rootContext.StopFuture(pid2).Wait())
+}
+
+func pinnedWorkerOf(pid *PID) *pinnedWorker {
+	proc, _ := system.ProcessRegistry.Get(pid)
+	return proc.(*ActorProcess).mailbox.(*defaultMailbox).dispatcher.(*pinnedWorker)
+}
+
+type benchmarkMessage struct{}
+
+func benchmarkDispatcher(b *testing.B, dispatcher Dispatcher, actors int) {
+	var wg sync.WaitGroup
+	props := PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Envelope().Message.(*benchmarkMessage); ok {
+			wg.Done()
+		}
+	}, WithDispatcher(dispatcher))
+
+	pids := make([]*PID, actors)
+	for i := range pids {
+		pids[i] = rootContext.Spawn(props)
+	}
+	defer func() {
+		for _, pid := range pids {
+			rootContext.Stop(pid)
+		}
+	}()
+
+	envelope := WrapEnvelope(&benchmarkMessage{})
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	wg.Add(b.N)
+	for i := 0; i < b.N; i++ {
+		rootContext.Send(pids[i%actors], envelope)
+	}
+	wg.Wait()
+}
+
+func BenchmarkDispatcher_Default(b *testing.B) {
+	benchmarkDispatcher(b, NewDefaultDispatcher(300), 10000)
+}
+
+func BenchmarkDispatcher_WorkerPool(b *testing.B) {
+	d := NewWorkerPoolDispatcher(runtime.GOMAXPROCS(0), 300)
+	defer d.Stop()
+	benchmarkDispatcher(b, d, 10000)
+	b.ReportMetric(float64(d.Overflows()), "overflows")
+}
+
+func BenchmarkDispatcher_DefaultSingleActor(b *testing.B) {
+	benchmarkDispatcher(b, NewDefaultDispatcher(300), 1)
+}
+
+func BenchmarkDispatcher_PinnedSingleActor(b *testing.B) {
+	benchmarkDispatcher(b, NewPinnedDispatcher(300), 1)
+}
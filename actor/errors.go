@@ -11,4 +11,16 @@ var (
 
 	// ErrDeadLetter is meaning you request to a unreachable PID.
 	ErrDeadLetter = errors.New("future: dead letter")
+
+	// ErrActorNotFound is returned by Lookup and ActorSelection when no local actor matches the path.
+	ErrActorNotFound = errors.New("lookup: actor not found")
+
+	// ErrNoFuture is returned by WaitAny when it is given no future.
+	ErrNoFuture = errors.New("future: no future to wait for")
+
+	// ErrStashFull is returned by Context.Stash when the stash of the actor reached its capacity.
+	ErrStashFull = errors.New("stash: full")
+
+	// ErrMailboxFull is meaning you request to a PID whose bounded mailbox rejected the message.
+	ErrMailboxFull = errors.New("future: mailbox full")
 )
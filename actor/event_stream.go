@@ -1,6 +1,7 @@
 package actor
 
 import (
+	"reflect"
 	"sync"
 	"sync/atomic"
 )
@@ -12,6 +13,9 @@ type EventMessage interface {
 // Handler defines a callback function that must be pass when subscribing.
 type Handler func(evt EventMessage)
 
+// Predicate filters the events passed to the handler of a subscription
+type Predicate func(evt EventMessage) bool
+
 type EventStream struct {
 	sync.RWMutex
 
@@ -30,11 +34,17 @@ func NewEventStream() *EventStream {
 }
 
 // Subscribe the given handler to the EventStream
-func (es *EventStream) Subscribe(handler Handler) *Subscription {
+func (es *EventStream) Subscribe(handler Handler, opts ...SubscribeOption) *Subscription {
 	sub := &Subscription{
 		handler: handler,
 		active:  1,
 	}
+	for _, opt := range opts {
+		opt(sub)
+	}
+	if sub.async != nil {
+		go sub.async.run(sub.handler)
+	}
 
 	es.Lock()
 	defer es.Unlock()
@@ -59,6 +69,9 @@ func (es *EventStream) Unsubscribe(sub *Subscription) {
 	if !sub.deActivate() {
 		return
 	}
+	if sub.async != nil {
+		close(sub.async.done)
+	}
 
 	if es.counter == 0 {
 		es.subscriptions = nil
@@ -91,7 +104,7 @@ func (es *EventStream) Publish(evt EventMessage) {
 	es.RUnlock()
 
 	for _, sub := range subs {
-		sub.handler(evt)
+		sub.publish(evt)
 	}
 }
 
@@ -101,13 +114,175 @@ func (es *EventStream) Length() int {
 	return len(es.subscriptions)
 }
 
+// Subscriptions describes the active subscriptions
+func (es *EventStream) Subscriptions() []SubscriptionInfo {
+	es.RLock()
+	defer es.RUnlock()
+
+	infos := make([]SubscriptionInfo, 0, len(es.subscriptions))
+	for _, sub := range es.subscriptions {
+		if sub.IsActive() {
+			infos = append(infos, sub.Info())
+		}
+	}
+
+	return infos
+}
+
+// SubscribeTo subscribes handler to the events of type T, the other events are skipped.
+func SubscribeTo[T EventMessage](es *EventStream, handler func(evt T), opts ...SubscribeOption) *Subscription {
+	opts = append([]SubscribeOption{func(sub *Subscription) {
+		sub.eventType = reflect.TypeOf((*T)(nil)).Elem()
+	}}, opts...)
+
+	return es.Subscribe(func(evt EventMessage) {
+		if e, ok := evt.(T); ok {
+			handler(e)
+		}
+	}, opts...)
+}
+
+// SubscribePID delivers the events to the mailbox of pid, sent through sender.
+// The subscription is cancelled once an event reaches the dead letters because pid no longer exists,
+// so a stopped subscriber does not keep generating DeadLetterEvent. The dead letters of pid while it is alive, e.g. a
+// bounded mailbox overflow, are skipped: sending them to the full mailbox would only produce more of them.
+func (es *EventStream) SubscribePID(sender SenderContext, pid *PID, opts ...SubscribeOption) *Subscription {
+	// sub is captured by the first option, before the subscription can receive any event
+	var sub *Subscription
+	opts = append([]SubscribeOption{func(s *Subscription) {
+		s.pid = pid
+		sub = s
+	}}, opts...)
+	// the dead letters of pid bypass the predicate, a filtering subscriber must be removed too once it is gone
+	opts = append(opts, func(s *Subscription) {
+		predicate := s.predicate
+		if predicate == nil {
+			return
+		}
+		s.predicate = func(evt EventMessage) bool {
+			return isDeadLetterOf(evt, pid) || predicate(evt)
+		}
+	})
+
+	registry := sender.ActorSystem().ProcessRegistry
+	return es.Subscribe(func(evt EventMessage) {
+		if isDeadLetterOf(evt, pid) {
+			if _, ok := registry.Get(pid); !ok {
+				es.Unsubscribe(sub)
+			}
+			return
+		}
+		sender.Send(pid, WrapEnvelope(evt))
+	}, opts...)
+}
+
+func isDeadLetterOf(evt EventMessage, pid *PID) bool {
+	dl, ok := evt.(*DeadLetterEvent)
+	return ok && dl.PID.Equal(pid)
+}
+
+// SubscribeOption configures a subscription
+type SubscribeOption func(sub *Subscription)
+
+// WithPredicate only passes the events of type T accepted by predicate to the handler.
+func WithPredicate[T EventMessage](predicate func(evt T) bool) SubscribeOption {
+	return func(sub *Subscription) {
+		sub.predicate = func(evt EventMessage) bool {
+			e, ok := evt.(T)
+			return ok && predicate(e)
+		}
+	}
+}
+
+// WithAsync calls the handler on its own goroutine instead of the goroutine of the publisher.
+// Up to buffer events are queued, the events published while the buffer is full are dropped and counted.
+func WithAsync(buffer int) SubscribeOption {
+	return func(sub *Subscription) {
+		sub.async = &asyncDelivery{
+			events: make(chan EventMessage, buffer),
+			done:   make(chan struct{}),
+		}
+	}
+}
+
+type asyncDelivery struct {
+	events  chan EventMessage
+	done    chan struct{}
+	dropped uint64
+}
+
+func (a *asyncDelivery) run(handler Handler) {
+	for {
+		select {
+		case evt := <-a.events:
+			handler(evt)
+		case <-a.done:
+			return
+		}
+	}
+}
+
+// SubscriptionInfo describes a subscription, see EventStream.Subscriptions.
+type SubscriptionInfo struct {
+	// EventType is the type of the events of a SubscribeTo subscription, empty for any event
+	EventType string
+	// PID is the target of a SubscribePID subscription
+	PID *PID
+	// Predicate is set when the events are filtered
+	Predicate bool
+	Async     bool
+	// Pending is the number of events in the buffer of an async subscription
+	Pending int
+	// Dropped is the number of events dropped by an async subscription
+	Dropped uint64
+}
+
 // Subscription is returned from the Subscribe function.
 //
 // This value and can be passed to Unsubscribe when the observer is no longer interested in receiving messages
 type Subscription struct {
-	id      int32
-	handler Handler
-	active  uint32
+	id        int32
+	handler   Handler
+	predicate Predicate
+	async     *asyncDelivery
+	eventType reflect.Type
+	pid       *PID
+	active    uint32
+}
+
+func (s *Subscription) publish(evt EventMessage) {
+	if s.predicate != nil && !s.predicate(evt) {
+		return
+	}
+
+	if s.async == nil {
+		s.handler(evt)
+		return
+	}
+
+	select {
+	case s.async.events <- evt:
+	default:
+		atomic.AddUint64(&s.async.dropped, 1)
+	}
+}
+
+// Info describes the subscription
+func (s *Subscription) Info() SubscriptionInfo {
+	info := SubscriptionInfo{
+		PID:       s.pid,
+		Predicate: s.predicate != nil,
+	}
+	if s.eventType != nil {
+		info.EventType = s.eventType.String()
+	}
+	if s.async != nil {
+		info.Async = true
+		info.Pending = len(s.async.events)
+		info.Dropped = atomic.LoadUint64(&s.async.dropped)
+	}
+
+	return info
 }
 
 func (s *Subscription) Activate() bool {
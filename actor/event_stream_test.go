@@ -1,8 +1,11 @@
 package actor_test
 
 import (
-	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
+
+	"github.com/colin1989/battery/actor"
+	"github.com/stretchr/testify/assert"
 )
 
 type TestEvent struct {
@@ -61,6 +64,122 @@ func TestEventStream_Publish(t *testing.T) {
 	assert.Equal(t, v, 100)
 }
 
+type OtherEvent struct{}
+
+func (e *OtherEvent) EventMessage() {}
+
+func TestEventStream_SubscribeTo(t *testing.T) {
+	es := actor.NewEventStream()
+
+	var got []int
+	actor.SubscribeTo(es, func(evt *TestEvent) {
+		got = append(got, evt.V)
+	}, actor.WithPredicate(func(evt *TestEvent) bool {
+		return evt.V%2 == 0
+	}))
+
+	for i := 1; i <= 4; i++ {
+		es.Publish(&TestEvent{V: i})
+	}
+	es.Publish(&OtherEvent{})
+	assert.Equal(t, []int{2, 4}, got)
+
+	infos := es.Subscriptions()
+	assert.Len(t, infos, 1)
+	assert.Equal(t, "*actor_test.TestEvent", infos[0].EventType)
+	assert.True(t, infos[0].Predicate)
+}
+
+func TestEventStream_SubscribePID(t *testing.T) {
+	system := actor.NewActorSystem()
+	events := make(chan *TestEvent, 1)
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if evt, ok := ctx.Envelope().Message.(*TestEvent); ok {
+			events <- evt
+		}
+	}))
+	length := system.EventStream.Length()
+
+	sub := system.EventStream.SubscribePID(system.Root, pid)
+	assert.Equal(t, pid, sub.Info().PID)
+
+	system.EventStream.Publish(&TestEvent{V: 1})
+	select {
+	case evt := <-events:
+		assert.Equal(t, 1, evt.V)
+	case <-time.After(time.Second):
+		t.Fatal("event not delivered to the actor")
+	}
+
+	_ = system.Root.StopFuture(pid).Wait()
+	system.EventStream.Publish(&TestEvent{V: 2})
+	assert.False(t, sub.IsActive(), "dead subscriber is unsubscribed")
+	assert.Equal(t, length, system.EventStream.Length())
+}
+
+func TestEventStream_SubscribePIDWhilePublishing(t *testing.T) {
+	system := actor.NewActorSystem()
+	es := actor.NewEventStream()
+	pid := actor.NewPID(system.Address(), "gone")
+
+	// the dead letter of pid may reach the handler before SubscribePID returns
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				es.Publish(&actor.DeadLetterEvent{PID: pid})
+			}
+		}
+	}()
+
+	sub := es.SubscribePID(system.Root, pid)
+	assert.Eventually(t, func() bool { return !sub.IsActive() }, time.Second, time.Millisecond)
+	close(stop)
+	<-done
+	assert.Equal(t, 0, es.Length())
+}
+
+func TestEventStream_Async(t *testing.T) {
+	es := actor.NewEventStream()
+
+	release := make(chan struct{})
+	received := make(chan int, 10)
+	sub := actor.SubscribeTo(es, func(evt *TestEvent) {
+		<-release
+		received <- evt.V
+	}, actor.WithAsync(2))
+
+	// the publisher is never blocked: the first event is in the handler, two are buffered, the last is dropped
+	es.Publish(&TestEvent{V: 1})
+	assert.Eventually(t, func() bool { return sub.Info().Pending == 0 }, time.Second, time.Millisecond)
+	for i := 2; i <= 4; i++ {
+		es.Publish(&TestEvent{V: i})
+	}
+
+	info := sub.Info()
+	assert.True(t, info.Async)
+	assert.Equal(t, 2, info.Pending)
+	assert.Equal(t, uint64(1), info.Dropped)
+
+	close(release)
+	for _, v := range []int{1, 2, 3} {
+		select {
+		case got := <-received:
+			assert.Equal(t, v, got)
+		case <-time.After(time.Second):
+			t.Fatal("event not delivered")
+		}
+	}
+
+	es.Unsubscribe(sub)
+	assert.Empty(t, es.Subscriptions())
+}
+
 func BenchmarkEventStream(b *testing.B) {
 	es := actor.NewEventStream()
 	subs := make([]*actor.Subscription, 10)
@@ -83,3 +202,41 @@ func BenchmarkEventStream(b *testing.B) {
 		}
 	}
 }
+
+func TestEventStream_SubscribePIDKeepsLiveSubscriber(t *testing.T) {
+	system := actor.NewActorSystem()
+	events := make(chan *TestEvent, 1)
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if evt, ok := ctx.Envelope().Message.(*TestEvent); ok {
+			events <- evt
+		}
+	}))
+	defer system.Root.Stop(pid)
+
+	sub := system.EventStream.SubscribePID(system.Root, pid)
+	defer system.EventStream.Unsubscribe(sub)
+
+	// e.g. a message dropped by the bounded mailbox of the subscriber
+	system.EventStream.Publish(&actor.DeadLetterEvent{PID: pid})
+	assert.True(t, sub.IsActive())
+
+	system.EventStream.Publish(&TestEvent{V: 1})
+	select {
+	case evt := <-events:
+		assert.Equal(t, 1, evt.V)
+	case <-time.After(time.Second):
+		t.Fatal("event not delivered to the actor")
+	}
+}
+
+func TestEventStream_SubscribePIDWithPredicateUnsubscribesDeadSubscriber(t *testing.T) {
+	system := actor.NewActorSystem()
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {}))
+	sub := system.EventStream.SubscribePID(system.Root, pid, actor.WithPredicate(func(evt *TestEvent) bool {
+		return evt.V > 0
+	}))
+
+	_ = system.Root.StopFuture(pid).Wait()
+	system.EventStream.Publish(&TestEvent{V: 1})
+	assert.False(t, sub.IsActive(), "dead subscriber is unsubscribed")
+}
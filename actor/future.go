@@ -1,22 +1,16 @@
 package actor
 
 import (
-	"log/slog"
+	"context"
+	"errors"
 	"sync"
-	"sync/atomic"
 	"time"
-	"unsafe"
 )
 
-// NewFuture creates and returns a new actor.Future with a timeout of duration d.
+// NewFuture creates and returns a new actor.Future with a timeout of duration d, a negative d never times out.
 func NewFuture(actorSystem *ActorSystem, d time.Duration) *Future {
 	ref := &futureProcess{Future{actorSystem: actorSystem, cond: sync.NewCond(&sync.Mutex{})}}
-	id := actorSystem.ProcessRegistry.NextId()
-
-	pid, ok := actorSystem.ProcessRegistry.Add(ref, "future"+id)
-	if !ok {
-		actorSystem.Logger().Warn("failed to register future process", slog.String("pid", pid.String()))
-	}
+	ref.pid = actorSystem.futures.add(ref)
 
 	//sysMetrics, ok := actorSystem.Extensions.Get(extensionId).(*Metrics)
 	//if ok && sysMetrics.enabled {
@@ -30,21 +24,8 @@ func NewFuture(actorSystem *ActorSystem, d time.Duration) *Future {
 	//	}
 	//}
 
-	ref.pid = pid
-
 	if d >= 0 {
-		tp := time.AfterFunc(d, func() {
-			ref.cond.L.Lock()
-			if ref.done {
-				ref.cond.L.Unlock()
-
-				return
-			}
-			ref.err = ErrTimeout
-			ref.cond.L.Unlock()
-			ref.Stop(pid)
-		})
-		atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(&ref.t)), unsafe.Pointer(tp))
+		actorSystem.futures.wheel.add(ref.pid.RequestId, d)
 	}
 
 	return &ref.Future
@@ -58,9 +39,8 @@ type Future struct {
 	done        bool
 	result      *MessageEnvelope
 	err         error
-	t           *time.Timer
 	pipes       []*PID
-	completions []func(res interface{}, err error)
+	completions []func(res *MessageEnvelope, err error)
 }
 
 // PID to the backing actor for the Future result.
@@ -123,10 +103,28 @@ func (f *Future) Wait() error {
 	return f.err
 }
 
-func (f *Future) continueWith(continuation func(res interface{}, err error)) {
+// ResultCtx waits for the future to resolve or ctx to be done, in which case ctx.Err() is returned.
+// The future itself is not cancelled, its response is dropped when it comes later.
+func (f *Future) ResultCtx(ctx context.Context) (*MessageEnvelope, error) {
+	done := make(chan struct{})
+	f.ContinueWith(func(*MessageEnvelope, error) {
+		close(done)
+	})
+
+	select {
+	case <-done:
+		return f.result, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// ContinueWith calls continuation once the future resolved, at once if it already did.
+// The continuation runs on the goroutine resolving the future while it is locked: it must not block
+// nor wait for this future, and it must not touch the state of an actor, use Context.ReenterAfter for that.
+func (f *Future) ContinueWith(continuation func(res *MessageEnvelope, err error)) {
 	f.cond.L.Lock()
-	defer f.cond.L.Unlock() // use defer as the continuation co
-	// uld blow up
+	defer f.cond.L.Unlock() // use defer as the continuation could blow up
 	if f.done {
 		continuation(f.result, f.err)
 	} else {
@@ -146,24 +144,23 @@ func (ref *futureProcess) SendUserMessage(pid *PID, envelop *MessageEnvelope) {
 
 	_, msg, _ := UnwrapEnvelope(envelop)
 
-	if _, ok := msg.(*DeadLetterResponse); ok {
-		ref.result = nil
-		ref.err = ErrDeadLetter
-	} else {
-		ref.result = envelop
+	switch msg.(type) {
+	case *DeadLetterResponse:
+		ref.complete(nil, ErrDeadLetter)
+	case *MailboxRejected:
+		ref.complete(nil, ErrMailboxFull)
+	default:
+		ref.complete(envelop, nil)
 	}
-
-	ref.Stop(pid)
 }
 
 func (ref *futureProcess) SendSystemMessage(pid *PID, message SystemMessage) {
 	defer ref.instrument()
-	ref.result = &MessageEnvelope{
+	ref.complete(&MessageEnvelope{
 		Header:  nil,
 		Message: message,
 		Sender:  nil,
-	}
-	ref.Stop(pid)
+	}, nil)
 }
 
 func (ref *futureProcess) instrument() {
@@ -186,6 +183,11 @@ func (ref *futureProcess) instrument() {
 }
 
 func (ref *futureProcess) Stop(pid *PID) {
+	ref.complete(nil, nil)
+}
+
+// complete resolves the future once, the first of the response, the timeout and Stop wins.
+func (ref *futureProcess) complete(result *MessageEnvelope, err error) {
 	ref.cond.L.Lock()
 	if ref.done {
 		ref.cond.L.Unlock()
@@ -194,21 +196,57 @@ func (ref *futureProcess) Stop(pid *PID) {
 	}
 
 	ref.done = true
-	tp := (*time.Timer)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&ref.t))))
-
-	if tp != nil {
-		tp.Stop()
-	}
-
-	ref.actorSystem.ProcessRegistry.Remove(pid)
+	ref.result = result
+	ref.err = err
+	ref.actorSystem.futures.remove(ref.pid.RequestId)
+	ref.actorSystem.futures.wheel.remove(ref.pid.RequestId)
 
 	ref.sendToPipes()
 	ref.runCompletions()
 	ref.cond.L.Unlock()
 
-	// 只能唤醒一个 goroutine
-	// ref.cond.Broadcast() 才能广播唤醒所有 goroutine
-	ref.cond.Signal()
+	// several goroutines may wait for the result
+	ref.cond.Broadcast()
+}
+
+// WaitAll waits for every future and returns their results in the same order.
+// The result of a failed future is nil and the returned error joins the errors of all failed futures.
+func WaitAll(futures ...*Future) ([]*MessageEnvelope, error) {
+	results := make([]*MessageEnvelope, len(futures))
+	errs := make([]error, 0)
+	for i, f := range futures {
+		res, err := f.Result()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		results[i] = res
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// WaitAny waits for the first future to resolve, successfully or not, and returns its index and result.
+func WaitAny(futures ...*Future) (int, *MessageEnvelope, error) {
+	if len(futures) == 0 {
+		return -1, nil, ErrNoFuture
+	}
+
+	type completion struct {
+		index int
+		res   *MessageEnvelope
+		err   error
+	}
+	first := make(chan completion, len(futures))
+	for i, f := range futures {
+		i := i
+		f.ContinueWith(func(res *MessageEnvelope, err error) {
+			first <- completion{index: i, res: res, err: err}
+		})
+	}
+
+	c := <-first
+	return c.index, c.res, c.err
 }
 
 // TODO: we could replace "pipes" with this
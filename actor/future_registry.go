@@ -0,0 +1,134 @@
+package actor
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// futureProcessID is the id of the process receiving the responses of every future of a system,
+	// the future itself is told apart by PID.RequestId
+	futureProcessID = "future"
+	futureShards    = 64
+)
+
+// futureRegistry is the single process the futures of a system are registered under.
+// A future is a PID{ID: "future", RequestId: n}, so creating one neither touches the ProcessRegistry nor starts a timer.
+type futureRegistry struct {
+	actorSystem *ActorSystem
+	pid         *PID
+	nextID      uint32
+	shards      [futureShards]futureShard
+	wheel       *timerWheel
+}
+
+type futureShard struct {
+	mu      sync.Mutex
+	futures map[uint32]*futureProcess
+}
+
+var _ Process = &futureRegistry{}
+
+func newFutureRegistry(actorSystem *ActorSystem) *futureRegistry {
+	r := &futureRegistry{actorSystem: actorSystem}
+	for i := range r.shards {
+		r.shards[i].futures = make(map[uint32]*futureProcess)
+	}
+	r.wheel = newTimerWheel(actorSystem.Config.Clock, timerWheelTick, r.timeout)
+	r.pid, _ = actorSystem.ProcessRegistry.Add(r, futureProcessID)
+
+	return r
+}
+
+// add registers ref and returns its PID.
+// Once the ids wrapped around, the ones of the futures still pending are skipped.
+func (r *futureRegistry) add(ref *futureProcess) *PID {
+	var id uint32
+	for {
+		id = atomic.AddUint32(&r.nextID, 1)
+		// 0 is the RequestId of every PID which is not a future
+		if id == 0 {
+			continue
+		}
+
+		shard := r.shard(id)
+		shard.mu.Lock()
+		_, taken := shard.futures[id]
+		if !taken {
+			shard.futures[id] = ref
+		}
+		shard.mu.Unlock()
+		if !taken {
+			break
+		}
+	}
+
+	return &PID{
+		Address:   r.actorSystem.ProcessRegistry.Address,
+		ID:        futureProcessID,
+		RequestId: id,
+	}
+}
+
+func (r *futureRegistry) get(id uint32) (*futureProcess, bool) {
+	shard := r.shard(id)
+	shard.mu.Lock()
+	ref, ok := shard.futures[id]
+	shard.mu.Unlock()
+
+	return ref, ok
+}
+
+func (r *futureRegistry) remove(id uint32) {
+	shard := r.shard(id)
+	shard.mu.Lock()
+	delete(shard.futures, id)
+	shard.mu.Unlock()
+}
+
+func (r *futureRegistry) shard(id uint32) *futureShard {
+	return &r.shards[id%futureShards]
+}
+
+// len returns the number of pending futures
+func (r *futureRegistry) len() int {
+	n := 0
+	for i := range r.shards {
+		r.shards[i].mu.Lock()
+		n += len(r.shards[i].futures)
+		r.shards[i].mu.Unlock()
+	}
+
+	return n
+}
+
+func (r *futureRegistry) timeout(id uint32) {
+	if ref, ok := r.get(id); ok {
+		ref.complete(nil, ErrTimeout)
+	}
+}
+
+// SendUserMessage resolves the future of pid, a late response of a resolved future is a dead letter
+func (r *futureRegistry) SendUserMessage(pid *PID, envelope *MessageEnvelope) {
+	ref, ok := r.get(pid.RequestId)
+	if !ok {
+		r.actorSystem.DeadLetter.SendUserMessage(pid, envelope)
+		return
+	}
+	ref.SendUserMessage(pid, envelope)
+}
+
+func (r *futureRegistry) SendSystemMessage(pid *PID, message SystemMessage) {
+	ref, ok := r.get(pid.RequestId)
+	if !ok {
+		r.actorSystem.DeadLetter.SendSystemMessage(pid, message)
+		return
+	}
+	ref.SendSystemMessage(pid, message)
+}
+
+func (r *futureRegistry) Stop(pid *PID) {
+	if ref, ok := r.get(pid.RequestId); ok {
+		ref.Stop(pid)
+	}
+}
@@ -1,6 +1,13 @@
 package actor
 
 import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -27,9 +34,8 @@ func TestFuture_PipeTo_Message(t *testing.T) {
 	f.PipeTo(p2)
 	f.PipeTo(p3)
 
-	ref, _ := system.ProcessRegistry.Get(f.pid)
-	assert.IsType(t, &futureProcess{}, ref)
-	fp, _ := ref.(*futureProcess)
+	fp, ok := system.futures.get(f.pid.RequestId)
+	assert.True(t, ok)
 
 	fp.SendUserMessage(f.pid, WrapEnvelope("hello"))
 	mp1.AssertExpectations(t)
@@ -53,7 +59,8 @@ func TestFuture_PipeTo_TimeoutSendsError(t *testing.T) {
 	mp3.On("SendUserMessage", p3, WrapEnvelope(ErrTimeout))
 
 	f := NewFuture(system, 10*time.Millisecond)
-	ref, _ := system.ProcessRegistry.Get(f.pid)
+	fp, ok := system.futures.get(f.pid.RequestId)
+	assert.True(t, ok)
 
 	f.PipeTo(p1)
 	f.PipeTo(p2)
@@ -62,9 +69,6 @@ func TestFuture_PipeTo_TimeoutSendsError(t *testing.T) {
 	err := f.Wait()
 	assert.Error(t, err)
 
-	assert.IsType(t, &futureProcess{}, ref)
-	fp, _ := ref.(*futureProcess)
-
 	mp1.AssertExpectations(t)
 	mp2.AssertExpectations(t)
 	mp3.AssertExpectations(t)
@@ -124,3 +128,220 @@ func TestFuture_Result_Success(t *testing.T) {
 	resp := assertFutureSuccess(future, t)
 	a.Equal(WrapEnvelope(EchoResponse{}), resp)
 }
+
+func TestFuture_ContinueWith(t *testing.T) {
+	future := NewFuture(system, time.Second)
+
+	results := make(chan *MessageEnvelope, 2)
+	future.ContinueWith(func(res *MessageEnvelope, err error) {
+		assert.NoError(t, err)
+		results <- res
+	})
+	rootContext.Send(future.PID(), WrapEnvelope(EchoResponse{}))
+	assert.Equal(t, WrapEnvelope(EchoResponse{}), waitFor(t, results))
+
+	// a resolved future runs the continuation at once
+	future.ContinueWith(func(res *MessageEnvelope, err error) {
+		results <- res
+	})
+	assert.Equal(t, WrapEnvelope(EchoResponse{}), waitFor(t, results))
+}
+
+func TestFuture_ResultWakesEveryWaiter(t *testing.T) {
+	future := NewFuture(system, time.Second)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := future.Result()
+			assert.NoError(t, err)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	rootContext.Send(future.PID(), WrapEnvelope(EchoResponse{}))
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	waitFor(t, done)
+}
+
+func TestFuture_ResultCtx(t *testing.T) {
+	future := NewFuture(system, time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := future.ResultCtx(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	rootContext.Send(future.PID(), WrapEnvelope(EchoResponse{}))
+	res, err := future.ResultCtx(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, WrapEnvelope(EchoResponse{}), res)
+}
+
+func TestWaitAll(t *testing.T) {
+	ok1, ok2 := NewFuture(system, time.Second), NewFuture(system, time.Second)
+	failed := NewFuture(system, 10*time.Millisecond)
+	rootContext.Send(ok1.PID(), WrapEnvelope(1))
+	rootContext.Send(ok2.PID(), WrapEnvelope(2))
+
+	results, err := WaitAll(ok1, failed, ok2)
+	assert.True(t, errors.Is(err, ErrTimeout))
+	assert.Equal(t, []*MessageEnvelope{WrapEnvelope(1), nil, WrapEnvelope(2)}, results)
+
+	results, err = WaitAll(ok1, ok2)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+}
+
+func TestWaitAny(t *testing.T) {
+	slow, fast := NewFuture(system, time.Second), NewFuture(system, time.Second)
+	rootContext.Send(fast.PID(), WrapEnvelope("fast"))
+
+	index, res, err := WaitAny(slow, fast)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, index)
+	assert.Equal(t, WrapEnvelope("fast"), res)
+
+	index, _, err = WaitAny()
+	assert.Equal(t, -1, index)
+	assert.Equal(t, ErrNoFuture, err)
+}
+
+func TestFuture_DispatchedByRequestId(t *testing.T) {
+	as := NewActorSystem()
+	defer func() { _ = as.ShutdownWithTimeout(context.Background()) }()
+
+	processes := func() int {
+		n := 0
+		for _, bucket := range as.ProcessRegistry.LocalPIDs.LocalPIDs {
+			n += bucket.Count()
+		}
+		return n
+	}
+	registered := processes()
+	first, second := NewFuture(as, time.Second), NewFuture(as, time.Second)
+	assert.Equal(t, registered, processes(), "a future should not register a process")
+	assert.Equal(t, first.PID().ID, second.PID().ID)
+	assert.NotEqual(t, first.PID().RequestId, second.PID().RequestId)
+
+	as.Root.Send(second.PID(), WrapEnvelope("second"))
+	res, err := second.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, WrapEnvelope("second"), res)
+	assert.Equal(t, 1, as.futures.len(), "the first future should still be pending")
+
+	// a response coming after the future resolved is a dead letter
+	as.Root.Send(second.PID(), WrapEnvelope("late"))
+	assert.Eventually(t, func() bool { return as.DeadLetter.Total() == 1 }, time.Second, time.Millisecond)
+
+	as.Root.Stop(first.PID())
+	assert.NoError(t, first.Wait())
+	assert.Equal(t, 0, as.futures.len())
+	assert.Equal(t, 0, as.futures.wheel.len(), "a resolved future should leave the timer wheel")
+}
+
+func TestFuture_RequestIdWrapAround(t *testing.T) {
+	as := NewActorSystem()
+	defer func() { _ = as.ShutdownWithTimeout(context.Background()) }()
+
+	pending := NewFuture(as, -1)
+	atomic.StoreUint32(&as.futures.nextID, math.MaxUint32-1)
+	wrapped := NewFuture(as, -1)
+	assert.Equal(t, uint32(math.MaxUint32), wrapped.PID().RequestId)
+
+	// the ids wrap around, 0 and the id of the pending future are skipped
+	next := NewFuture(as, -1)
+	assert.NotEqual(t, pending.PID().RequestId, next.PID().RequestId)
+	assert.NotZero(t, next.PID().RequestId)
+
+	as.Root.Send(pending.PID(), WrapEnvelope("pending"))
+	res, err := pending.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, WrapEnvelope("pending"), res)
+	assert.Equal(t, 2, as.futures.len())
+}
+
+func TestFuture_Watch(t *testing.T) {
+	pid := rootContext.Spawn(PropsFromFunc(func(Context) {}))
+	futures := []*Future{NewFuture(system, time.Second), NewFuture(system, time.Second)}
+	for _, f := range futures {
+		pid.sendSystemMessage(system, &Watch{Watcher: f.PID()})
+	}
+	rootContext.Stop(pid)
+
+	results, err := WaitAll(futures...)
+	assert.NoError(t, err)
+	for _, res := range results {
+		assert.IsType(t, &Terminated{}, res.Message)
+	}
+}
+
+// legacyFuture is a future registered as its own process with its own timer, for comparison
+type legacyFuture struct {
+	Future
+	t Timer
+}
+
+func newLegacyFuture(as *ActorSystem, d time.Duration) *legacyFuture {
+	ref := &legacyFuture{Future: Future{actorSystem: as, cond: sync.NewCond(&sync.Mutex{})}}
+	ref.pid, _ = as.ProcessRegistry.Add(ref, "future"+as.ProcessRegistry.NextId())
+	ref.cond.L.Lock()
+	ref.t = as.Config.Clock.AfterFunc(d, func() { ref.Stop(ref.pid) })
+	ref.cond.L.Unlock()
+	return ref
+}
+
+func (ref *legacyFuture) SendUserMessage(pid *PID, envelope *MessageEnvelope) {
+	ref.cond.L.Lock()
+	ref.result = envelope
+	ref.cond.L.Unlock()
+	ref.Stop(pid)
+}
+
+func (ref *legacyFuture) SendSystemMessage(*PID, SystemMessage) {}
+
+func (ref *legacyFuture) Stop(pid *PID) {
+	ref.cond.L.Lock()
+	if !ref.done {
+		ref.done = true
+		ref.t.Stop()
+		ref.actorSystem.ProcessRegistry.Remove(pid)
+	}
+	ref.cond.L.Unlock()
+	ref.cond.Broadcast()
+}
+
+func BenchmarkFuture(b *testing.B) {
+	as := NewActorSystem(WithLoggerFactory(func(*ActorSystem) *slog.Logger {
+		return slog.New(slog.NewTextHandler(io.Discard, nil))
+	}))
+	response := WrapEnvelope(EchoResponse{})
+
+	b.Run("RequestId", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				f := NewFuture(as, 5*time.Second)
+				f.PID().sendUserMessage(as, response)
+				_, _ = f.Result()
+			}
+		})
+	})
+
+	b.Run("ProcessPerFuture", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				f := newLegacyFuture(as, 5*time.Second)
+				f.PID().sendUserMessage(as, response)
+				_, _ = f.Result()
+			}
+		})
+	})
+}
@@ -0,0 +1,207 @@
+package actor
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// inspectTimeout is how long Inspect waits for the actors to describe themselves
+var inspectTimeout = time.Second
+
+// ActorInfo describes a running actor and its children.
+type ActorInfo struct {
+	PID          *PID          `json:"pid"`
+	Parent       *PID          `json:"parent,omitempty"`
+	Type         string        `json:"type,omitempty"`
+	State        string        `json:"state"`
+	MailboxCount int           `json:"mailboxCount"`
+	Watchers     []*PID        `json:"watchers,omitempty"`
+	StartedAt    time.Time     `json:"startedAt"`
+	Uptime       time.Duration `json:"-"`
+	// Unresponsive is set when the actor did not answer in time, e.g. it is blocked in Receive.
+	// Only PID, Parent, State, MailboxCount and StartedAt are filled then.
+	Unresponsive bool         `json:"unresponsive,omitempty"`
+	Children     []*ActorInfo `json:"children,omitempty"`
+}
+
+func (info *ActorInfo) MarshalJSON() ([]byte, error) {
+	type alias ActorInfo
+	return json.Marshal(&struct {
+		*alias
+		Uptime string `json:"uptime"`
+	}{
+		alias:  (*alias)(info),
+		Uptime: info.Uptime.Round(time.Millisecond).String(),
+	})
+}
+
+// inspectActor asks an actor to describe itself from its own goroutine
+type inspectActor struct {
+	reply chan *ActorInfo
+}
+
+func (*inspectActor) SystemMessage() {}
+
+// Inspect returns the tree of the running actors, the roots are the actors spawned by the root context.
+func (as *ActorSystem) Inspect() []*ActorInfo {
+	type pending struct {
+		ctx   *actorContext
+		pid   *PID
+		reply chan *ActorInfo
+	}
+
+	var requests []pending
+	for _, pid := range as.ProcessRegistry.PIDs() {
+		ref, ok := as.ProcessRegistry.Get(pid)
+		if !ok {
+			continue
+		}
+		proc, ok := ref.(*ActorProcess)
+		if !ok || proc.ctx == nil {
+			continue
+		}
+
+		reply := make(chan *ActorInfo, 1)
+		proc.SendSystemMessage(pid, &inspectActor{reply: reply})
+		requests = append(requests, pending{ctx: proc.ctx, pid: pid, reply: reply})
+	}
+
+	deadline := time.NewTimer(inspectTimeout)
+	defer deadline.Stop()
+
+	timedOut := false
+	infos := make(map[string]*ActorInfo, len(requests))
+	for _, r := range requests {
+		var info *ActorInfo
+		if !timedOut {
+			select {
+			case info = <-r.reply:
+			case <-deadline.C:
+				timedOut = true
+			}
+		}
+		if info == nil {
+			select {
+			case info = <-r.reply:
+			default:
+				info = r.ctx.unresponsiveInfo(r.pid)
+			}
+		}
+		infos[info.PID.ID] = info
+	}
+
+	return buildActorTree(infos)
+}
+
+// InspectJSON writes the actor tree as indented JSON
+func (as *ActorSystem) InspectJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(as.Inspect())
+}
+
+// InspectOnSignal writes the actor tree to w every time one of signals is received, e.g. syscall.SIGUSR1.
+// Call the returned function to stop listening.
+func (as *ActorSystem) InspectOnSignal(w io.Writer, signals ...os.Signal) func() {
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, signals...)
+
+	go func() {
+		for {
+			select {
+			case <-ch:
+				if err := as.InspectJSON(w); err != nil {
+					as.Logger().Error("inspect actor system failed", slog.Any("err", err))
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
+
+func buildActorTree(infos map[string]*ActorInfo) []*ActorInfo {
+	var roots []*ActorInfo
+	for _, info := range infos {
+		if info.Parent != nil {
+			if parent, ok := infos[info.Parent.ID]; ok {
+				parent.Children = append(parent.Children, info)
+				continue
+			}
+		}
+		roots = append(roots, info)
+	}
+
+	for _, info := range infos {
+		sortActorInfos(info.Children)
+	}
+	sortActorInfos(roots)
+
+	return roots
+}
+
+func sortActorInfos(infos []*ActorInfo) {
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].PID.ID < infos[j].PID.ID
+	})
+}
+
+// info runs on the actor goroutine
+func (ctx *actorContext) info() *ActorInfo {
+	info := ctx.unresponsiveInfo(ctx.self)
+	info.Unresponsive = false
+	info.Type = reflect.TypeOf(ctx.actor).String()
+	if ctx.extras != nil {
+		// the reply is read on another goroutine while the set keeps changing
+		info.Watchers = append([]*PID(nil), ctx.extras.watchers.Values()...)
+	}
+
+	return info
+}
+
+// unresponsiveInfo only reads what is safe to read from another goroutine
+func (ctx *actorContext) unresponsiveInfo(pid *PID) *ActorInfo {
+	info := &ActorInfo{
+		PID:          pid,
+		Parent:       ctx.parent,
+		State:        stateName(atomic.LoadInt32(&ctx.state)),
+		StartedAt:    ctx.startedAt,
+		Uptime:       ctx.actorSystem.Config.Clock.Now().Sub(ctx.startedAt),
+		Unresponsive: true,
+	}
+	if ref, ok := ctx.actorSystem.ProcessRegistry.Get(pid); ok {
+		if proc, ok := ref.(*ActorProcess); ok {
+			info.MailboxCount = proc.mailbox.Count()
+		}
+	}
+
+	return info
+}
+
+func stateName(state int32) string {
+	switch state {
+	case stateAlive:
+		return "alive"
+	case stateRestarting:
+		return "restarting"
+	case stateStopping:
+		return "stopping"
+	case stateStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
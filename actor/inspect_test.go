@@ -0,0 +1,74 @@
+package actor
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type spawnChildren struct{ n int }
+
+func TestActorSystem_Inspect(t *testing.T) {
+	as := NewActorSystem()
+	defer func() { inspectTimeout = time.Second }()
+	inspectTimeout = 100 * time.Millisecond
+
+	ready := make(chan struct{}, 1)
+	parent, err := as.Root.SpawnNamed(PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Envelope().Message.(*spawnChildren); ok {
+			for i := 0; i < msg.n; i++ {
+				ctx.Spawn(PropsFromFunc(func(ctx Context) {}))
+			}
+			ready <- struct{}{}
+		}
+	}), "parent")
+	require.NoError(t, err)
+	as.Root.Send(parent, WrapEnvelope(&spawnChildren{n: 2}))
+	<-ready
+
+	release := make(chan struct{})
+	defer close(release)
+	blocked := make(chan struct{})
+	stuck, err := as.Root.SpawnNamed(PropsFromFunc(func(ctx Context) {
+		switch ctx.Envelope().Message.(type) {
+		case *Started:
+			ctx.Watch(parent)
+		case *blockMessage:
+			close(blocked)
+			<-release
+		}
+	}), "stuck")
+	require.NoError(t, err)
+	as.Root.Send(stuck, WrapEnvelope(&blockMessage{}))
+	as.Root.Send(stuck, WrapEnvelope(&pingMessage{}))
+	<-blocked
+
+	roots := as.Inspect()
+	require.Len(t, roots, 2)
+
+	p := roots[0]
+	assert.Equal(t, "parent", p.PID.ID)
+	assert.Equal(t, "actor.ReceiveFunc", p.Type)
+	assert.Equal(t, "alive", p.State)
+	assert.Equal(t, []*PID{stuck}, p.Watchers)
+	assert.Len(t, p.Children, 2)
+	assert.True(t, p.Children[0].Parent.Equal(parent))
+	assert.Greater(t, p.Uptime, time.Duration(0))
+
+	s := roots[1]
+	assert.Equal(t, "stuck", s.PID.ID)
+	assert.True(t, s.Unresponsive)
+	assert.Equal(t, 1, s.MailboxCount)
+
+	var buf bytes.Buffer
+	require.NoError(t, as.InspectJSON(&buf))
+	var dump []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &dump))
+	assert.Len(t, dump, 2)
+	assert.Contains(t, dump[0], "uptime")
+	assert.Len(t, dump[0]["children"], 2)
+}
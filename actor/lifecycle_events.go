@@ -0,0 +1,32 @@
+package actor
+
+// ActorSpawnedEvent is published on the EventStream when an actor has been registered by the default spawner
+type ActorSpawnedEvent struct {
+	PID    *PID
+	Parent *PID
+}
+
+var _ EventMessage = &ActorSpawnedEvent{}
+
+func (*ActorSpawnedEvent) EventMessage() {}
+
+// ActorStoppedEvent is published on the EventStream when an actor has stopped and its watchers are notified
+type ActorStoppedEvent struct {
+	PID    *PID
+	Parent *PID
+	Reason TerminatedReason
+}
+
+var _ EventMessage = &ActorStoppedEvent{}
+
+func (*ActorStoppedEvent) EventMessage() {}
+
+// ActorRestartedEvent is published on the EventStream when an actor has been restarted by its supervisor,
+// the failure is described by the SupervisorEvent published before.
+type ActorRestartedEvent struct {
+	PID *PID
+}
+
+var _ EventMessage = &ActorRestartedEvent{}
+
+func (*ActorRestartedEvent) EventMessage() {}
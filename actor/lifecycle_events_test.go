@@ -0,0 +1,34 @@
+package actor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycleEvents(t *testing.T) {
+	as := NewActorSystem()
+	spawned := make(chan *ActorSpawnedEvent, 10)
+	restarted := make(chan *ActorRestartedEvent, 10)
+	stopped := make(chan *ActorStoppedEvent, 10)
+	SubscribeTo(as.EventStream, func(evt *ActorSpawnedEvent) { spawned <- evt })
+	SubscribeTo(as.EventStream, func(evt *ActorRestartedEvent) { restarted <- evt })
+	SubscribeTo(as.EventStream, func(evt *ActorStoppedEvent) { stopped <- evt })
+
+	pid := as.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Envelope().Message.(*failMessage); ok {
+			panic(errChildFailed)
+		}
+	}))
+	evt := waitFor(t, spawned)
+	assert.Equal(t, pid, evt.PID)
+	assert.Nil(t, evt.Parent)
+
+	as.Root.Send(pid, WrapEnvelope(&failMessage{}))
+	assert.Equal(t, pid, waitFor(t, restarted).PID)
+
+	assert.NoError(t, as.Root.StopFuture(pid).Wait())
+	stop := waitFor(t, stopped)
+	assert.Equal(t, pid, stop.PID)
+	assert.Equal(t, TerminatedReason_Stopped, stop.Reason)
+}
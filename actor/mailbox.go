@@ -1,7 +1,9 @@
 package actor
 
 import (
+	"fmt"
 	"runtime"
+	"slices"
 	"sync/atomic"
 
 	"github.com/colin1989/battery/blog"
@@ -17,6 +19,20 @@ type MailboxMiddleware interface {
 	MailboxEmpty()
 }
 
+// MailboxInvokeMiddleware is an optional extension of MailboxMiddleware.
+// MessageInvoking is called right before a message is handed to the invoker, so the time spent
+// in the actor is the time between MessageInvoking and MessageReceived. Both are called for every dequeued message,
+// SuspendMailbox and ResumeMailbox included, and MessageReceived also follows a message the actor panicked on.
+type MailboxInvokeMiddleware interface {
+	MessageInvoking(message interface{})
+}
+
+// MailboxDropMiddleware is an optional extension of MailboxMiddleware.
+// MessageDropped is called for a posted user message the mailbox discarded before invoking it, e.g. evicted by DropOldest.
+type MailboxDropMiddleware interface {
+	MessageDropped(message interface{})
+}
+
 type Mailbox interface {
 	Start()
 	Count() int
@@ -68,6 +84,15 @@ func newDefaultMailbox() Mailbox {
 }
 
 func (m *defaultMailbox) Start() {
+	for _, ms := range m.middlewares {
+		ms.MailboxStarted()
+	}
+}
+
+// use appends mailbox middlewares, it must be called before the mailbox is started.
+// The middlewares given to the producer are shared by all its mailboxes, so they are copied first.
+func (m *defaultMailbox) use(middlewares ...MailboxMiddleware) {
+	m.middlewares = append(slices.Clip(m.middlewares), middlewares...)
 }
 
 func (m *defaultMailbox) Count() int {
@@ -77,15 +102,45 @@ func (m *defaultMailbox) Count() int {
 func (m *defaultMailbox) PostUserMessage(message *MessageEnvelope) {
 	m.userMailbox.Push(message)
 	atomic.AddInt32(&m.userMessages, 1)
+	m.posted(message)
 	m.schedule()
 }
 
 func (m *defaultMailbox) PostSystemMessage(message SystemMessage) {
 	m.systemMailbox.Push(message)
 	atomic.AddInt32(&m.sysMessages, 1)
+	m.posted(message)
 	m.schedule()
 }
 
+func (m *defaultMailbox) posted(message interface{}) {
+	for _, ms := range m.middlewares {
+		ms.MessagePosted(message)
+	}
+}
+
+func (m *defaultMailbox) dropped(message interface{}) {
+	for _, ms := range m.middlewares {
+		if dm, ok := ms.(MailboxDropMiddleware); ok {
+			dm.MessageDropped(message)
+		}
+	}
+}
+
+func (m *defaultMailbox) invoking(message interface{}) {
+	for _, ms := range m.middlewares {
+		if im, ok := ms.(MailboxInvokeMiddleware); ok {
+			im.MessageInvoking(message)
+		}
+	}
+}
+
+func (m *defaultMailbox) received(message interface{}) {
+	for _, ms := range m.middlewares {
+		ms.MessageReceived(message)
+	}
+}
+
 func (m *defaultMailbox) RegisterHandlers(invoker Invoker, dispatcher Dispatcher) {
 	m.invoker = invoker
 	m.dispatcher = dispatcher
@@ -112,18 +167,26 @@ process:
 			goto process
 		}
 	}
+
+	for _, ms := range m.middlewares {
+		ms.MailboxEmpty()
+	}
 }
 
 func (m *defaultMailbox) run() {
+	var message interface{}
 	var envelope *MessageEnvelope
 	var systemMessage SystemMessage
 	var ok bool
 
 	defer func() {
 		if r := recover(); r != nil {
-			//plog.Info("[ACTOR] Recovering", log.Object("actor", m.invoker), log.Object("reason", r), log.Stack())
-			blog.CallerStack(r.(error), 1)
-			m.invoker.EscalateFailure(r, envelope)
+			blog.CallerStack(fmt.Errorf("actor panic: %v", r), 1)
+			// the failed message was handed to the invoker, close its MessageInvoking
+			if message != nil {
+				m.received(message)
+			}
+			m.invoker.EscalateFailure(r, message)
 		}
 	}()
 
@@ -139,17 +202,20 @@ func (m *defaultMailbox) run() {
 		// keep processing system messages until queue is empty
 		if systemMessage, ok = m.systemMailbox.Pop(); systemMessage != nil && ok {
 			atomic.AddInt32(&m.sysMessages, -1)
-			switch systemMessage {
-			//case *SuspendMailbox:
-			//	atomic.StoreInt32(&m.suspended, 1)
-			//case *ResumeMailbox:
-			//	atomic.StoreInt32(&m.suspended, 0)
+			// every dequeued message gets both hooks, the control messages of the mailbox included
+			message = systemMessage
+			m.invoking(systemMessage)
+			switch systemMessage.(type) {
+			case *SuspendMailbox:
+				atomic.StoreInt32(&m.suspended, 1)
+			case *ResumeMailbox:
+				atomic.StoreInt32(&m.suspended, 0)
+				// the actor runs what it held back while suspended
+				m.invoker.InvokeSystemMessage(systemMessage)
 			default:
 				m.invoker.InvokeSystemMessage(systemMessage)
 			}
-			//for _, ms := range m.middlewares {
-			//	ms.MessageReceived(envelope)
-			//}
+			m.received(systemMessage)
 			continue
 		}
 
@@ -160,10 +226,10 @@ func (m *defaultMailbox) run() {
 
 		if envelope, ok = m.userMailbox.Pop(); envelope != nil && ok {
 			atomic.AddInt32(&m.userMessages, -1)
+			message = envelope
+			m.invoking(envelope)
 			m.invoker.InvokeUserMessage(envelope)
-			//for _, ms := range m.middlewares {
-			//	ms.MessageReceived(envelope)
-			//}
+			m.received(envelope)
 		} else {
 			return
 		}
@@ -0,0 +1,220 @@
+package actor
+
+import (
+	"sync"
+	"time"
+)
+
+// MailboxStats is a point in time copy of the statistics collected for one actor mailbox
+type MailboxStats struct {
+	PID *PID
+	// QueueDepth is the number of user messages waiting in the mailbox
+	QueueDepth int
+	// Posted and Received count user messages
+	Posted   uint64
+	Received uint64
+	// Dropped counts the posted user messages the mailbox discarded before they were received
+	Dropped uint64
+	// Throughput is the number of user messages received during the last full second
+	Throughput uint64
+	// AvgLatency and MaxLatency measure the time between a user message being posted and dequeued
+	AvgLatency time.Duration
+	MaxLatency time.Duration
+	// AvgInvokeTime and MaxInvokeTime measure the time spent in InvokeUserMessage
+	AvgInvokeTime time.Duration
+	MaxInvokeTime time.Duration
+}
+
+// mailboxStatistics is the per mailbox middleware installed by WithMailboxStats.
+type mailboxStatistics struct {
+	pid   *PID
+	count func() int
+	clock Clock
+
+	mu           sync.Mutex
+	postedAt     map[*MessageEnvelope]time.Time
+	invokedAt    time.Time
+	posted       uint64
+	received     uint64
+	dropped      uint64
+	totalLatency time.Duration
+	maxLatency   time.Duration
+	latencies    uint64
+	totalInvoke  time.Duration
+	maxInvoke    time.Duration
+	windowStart  time.Time
+	windowCount  uint64
+	throughput   uint64
+}
+
+var (
+	_ MailboxMiddleware       = &mailboxStatistics{}
+	_ MailboxInvokeMiddleware = &mailboxStatistics{}
+	_ MailboxDropMiddleware   = &mailboxStatistics{}
+)
+
+func newMailboxStatistics(pid *PID, count func() int, clock Clock) *mailboxStatistics {
+	return &mailboxStatistics{
+		pid:      pid,
+		count:    count,
+		clock:    clock,
+		postedAt: make(map[*MessageEnvelope]time.Time),
+	}
+}
+
+func (s *mailboxStatistics) MailboxStarted() {
+	s.mu.Lock()
+	s.windowStart = s.clock.Now()
+	s.mu.Unlock()
+}
+
+func (s *mailboxStatistics) MessagePosted(message interface{}) {
+	envelope, ok := message.(*MessageEnvelope)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	s.posted++
+	// the same envelope may be posted twice before it is dequeued, keep the oldest timestamp
+	if _, exists := s.postedAt[envelope]; !exists {
+		s.postedAt[envelope] = s.clock.Now()
+	}
+	s.mu.Unlock()
+}
+
+func (s *mailboxStatistics) MessageInvoking(message interface{}) {
+	envelope, ok := message.(*MessageEnvelope)
+	if !ok {
+		return
+	}
+
+	now := s.clock.Now()
+	s.mu.Lock()
+	if postedAt, exists := s.postedAt[envelope]; exists {
+		delete(s.postedAt, envelope)
+		latency := now.Sub(postedAt)
+		s.totalLatency += latency
+		s.latencies++
+		if latency > s.maxLatency {
+			s.maxLatency = latency
+		}
+	}
+	s.invokedAt = now
+	s.mu.Unlock()
+}
+
+func (s *mailboxStatistics) MessageReceived(message interface{}) {
+	if _, ok := message.(*MessageEnvelope); !ok {
+		return
+	}
+
+	now := s.clock.Now()
+	s.mu.Lock()
+	s.received++
+	invoke := now.Sub(s.invokedAt)
+	s.totalInvoke += invoke
+	if invoke > s.maxInvoke {
+		s.maxInvoke = invoke
+	}
+
+	s.rollWindow(now)
+	s.windowCount++
+	s.mu.Unlock()
+}
+
+// MessageDropped forgets the posting time of a message that will never be invoked
+func (s *mailboxStatistics) MessageDropped(message interface{}) {
+	envelope, ok := message.(*MessageEnvelope)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	s.dropped++
+	delete(s.postedAt, envelope)
+	s.mu.Unlock()
+}
+
+func (s *mailboxStatistics) MailboxEmpty() {}
+
+// rollWindow closes the throughput window once a second has passed, must be called with mu held.
+func (s *mailboxStatistics) rollWindow(now time.Time) {
+	elapsed := now.Sub(s.windowStart)
+	if elapsed < time.Second {
+		return
+	}
+
+	if elapsed < 2*time.Second {
+		s.throughput = s.windowCount
+	} else {
+		// no message for a whole window
+		s.throughput = 0
+	}
+	s.windowStart = now
+	s.windowCount = 0
+}
+
+func (s *mailboxStatistics) snapshot() MailboxStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rollWindow(s.clock.Now())
+	stats := MailboxStats{
+		PID:           s.pid,
+		QueueDepth:    s.count(),
+		Posted:        s.posted,
+		Received:      s.received,
+		Dropped:       s.dropped,
+		Throughput:    s.throughput,
+		MaxLatency:    s.maxLatency,
+		MaxInvokeTime: s.maxInvoke,
+	}
+	if s.latencies > 0 {
+		stats.AvgLatency = s.totalLatency / time.Duration(s.latencies)
+	}
+	if s.received > 0 {
+		stats.AvgInvokeTime = s.totalInvoke / time.Duration(s.received)
+	}
+
+	return stats
+}
+
+// mailboxStatsRegistry indexes the statistics of every actor spawned with WithMailboxStats.
+type mailboxStatsRegistry struct {
+	stats sync.Map // PID.ID -> *mailboxStatistics
+}
+
+func (r *mailboxStatsRegistry) register(pid *PID, mb Mailbox, clock Clock) {
+	m, ok := mb.(interface{ use(...MailboxMiddleware) })
+	if !ok {
+		return
+	}
+
+	stats := newMailboxStatistics(pid, mb.Count, clock)
+	m.use(stats)
+	r.stats.Store(pid.ID, stats)
+}
+
+func (r *mailboxStatsRegistry) remove(pid *PID) {
+	r.stats.Delete(pid.ID)
+}
+
+func (r *mailboxStatsRegistry) get(pid *PID) (MailboxStats, bool) {
+	v, ok := r.stats.Load(pid.ID)
+	if !ok {
+		return MailboxStats{}, false
+	}
+
+	return v.(*mailboxStatistics).snapshot(), true
+}
+
+func (r *mailboxStatsRegistry) all() []MailboxStats {
+	var all []MailboxStats
+	r.stats.Range(func(_, v any) bool {
+		all = append(all, v.(*mailboxStatistics).snapshot())
+		return true
+	})
+
+	return all
+}
@@ -0,0 +1,180 @@
+package actor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingMiddleware struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recordingMiddleware) record(event string) {
+	r.mu.Lock()
+	r.events = append(r.events, event)
+	r.mu.Unlock()
+}
+
+func (r *recordingMiddleware) MailboxStarted()             { r.record("started") }
+func (r *recordingMiddleware) MessagePosted(interface{})   { r.record("posted") }
+func (r *recordingMiddleware) MessageReceived(interface{}) { r.record("received") }
+func (r *recordingMiddleware) MailboxEmpty()               { r.record("empty") }
+func (r *recordingMiddleware) MessageInvoking(interface{}) { r.record("invoking") }
+
+func (r *recordingMiddleware) count(event string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for _, e := range r.events {
+		if e == event {
+			n++
+		}
+	}
+	return n
+}
+
+func TestMailboxMiddleware_HooksArePairedForSuspendAndResume(t *testing.T) {
+	middleware := &recordingMiddleware{}
+	restarted := make(chan struct{}, 1)
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Envelope().Message.(type) {
+		case *Restarting:
+			restarted <- struct{}{}
+		case *blockMessage:
+			panic("suspend the mailbox")
+		}
+	}, WithMailbox(UnboundedLockfree(middleware))))
+	defer rootContext.Stop(pid)
+
+	rootContext.Send(pid, WrapEnvelope(&blockMessage{}))
+	waitFor(t, restarted)
+
+	// Started, the failing message, Suspend, Restart and Resume
+	assert.Eventually(t, func() bool { return middleware.count("received") >= 5 }, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return middleware.count("invoking") == middleware.count("received")
+	}, time.Second, time.Millisecond)
+}
+
+func TestMailboxMiddleware_HooksAreInvoked(t *testing.T) {
+	middleware := &recordingMiddleware{}
+	done := make(chan struct{})
+	props := PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Envelope().Message.(string); ok {
+			close(done)
+		}
+	}, WithMailbox(UnboundedLockfree(middleware)))
+
+	pid := rootContext.Spawn(props)
+	defer rootContext.Stop(pid)
+
+	rootContext.Send(pid, WrapEnvelope("hello"))
+	waitFor(t, done)
+
+	assert.Eventually(t, func() bool { return middleware.count("empty") > 0 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, middleware.count("started"))
+	// Started system message and one user message
+	assert.Equal(t, 2, middleware.count("posted"))
+	assert.Equal(t, 2, middleware.count("received"))
+}
+
+func TestMailboxStats_CollectsPerPID(t *testing.T) {
+	pid, release, received := spawnBlockedActor(t, nil)
+	defer rootContext.Stop(pid)
+
+	_, ok := system.MailboxStats(pid)
+	assert.False(t, ok, "stats are opt-in")
+
+	props := PropsFromFunc(func(ctx Context) {
+		if v, ok := ctx.Envelope().Message.(int); ok {
+			time.Sleep(5 * time.Millisecond)
+			received <- v
+		}
+	}, WithMailboxStats())
+	statsPID := rootContext.Spawn(props)
+
+	for i := 0; i < 3; i++ {
+		rootContext.Send(statsPID, WrapEnvelope(i))
+	}
+	close(release)
+	drain(received, 3)
+
+	assert.Eventually(t, func() bool {
+		stats, ok := system.MailboxStats(statsPID)
+		return ok && stats.Received == 3
+	}, time.Second, 10*time.Millisecond)
+
+	stats, _ := system.MailboxStats(statsPID)
+	assert.Equal(t, uint64(3), stats.Posted)
+	assert.Equal(t, 0, stats.QueueDepth)
+	assert.GreaterOrEqual(t, stats.AvgInvokeTime, 5*time.Millisecond)
+	assert.GreaterOrEqual(t, stats.MaxLatency, stats.AvgLatency)
+
+	_ = rootContext.StopFuture(statsPID).Wait()
+	_, ok = system.MailboxStats(statsPID)
+	assert.False(t, ok, "stats are removed once the actor stopped")
+}
+
+func TestMailboxStats_DroppedAndClock(t *testing.T) {
+	clock := &manualClock{now: time.Unix(0, 0)}
+	as := NewActorSystem(WithClock(clock))
+	defer func() { _ = as.ShutdownWithTimeout(context.Background()) }()
+
+	release, blocked := make(chan struct{}), make(chan struct{})
+	received := make(chan int, 10)
+	pid := as.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Envelope().Message.(type) {
+		case *blockMessage:
+			close(blocked)
+			<-release
+		case int:
+			clock.add(5 * time.Millisecond)
+			received <- msg
+		}
+	}, WithMailbox(Bounded(2, DropOldest)), WithMailboxStats()))
+	defer as.Root.Stop(pid)
+
+	as.Root.Send(pid, WrapEnvelope(&blockMessage{}))
+	waitFor(t, blocked)
+	for i := 1; i <= 4; i++ {
+		as.Root.Send(pid, WrapEnvelope(i))
+	}
+	clock.add(10 * time.Millisecond)
+	close(release)
+	assert.Equal(t, 3, waitFor(t, received))
+	assert.Equal(t, 4, waitFor(t, received))
+
+	assert.Eventually(t, func() bool {
+		stats, _ := as.MailboxStats(pid)
+		return stats.Received == 3
+	}, time.Second, time.Millisecond)
+
+	stats, _ := as.MailboxStats(pid)
+	assert.Equal(t, uint64(2), stats.Dropped)
+	// 3 and 4 were posted at 0 and invoked at 10ms and 15ms
+	assert.Equal(t, 15*time.Millisecond, stats.MaxLatency)
+	assert.Equal(t, 10*time.Millisecond, stats.MaxInvokeTime)
+
+	v, _ := as.mailboxStats.stats.Load(pid.ID)
+	assert.Empty(t, v.(*mailboxStatistics).postedAt, "the dropped messages should be forgotten")
+}
+
+func TestMailboxStats_ProducerMiddlewaresAreNotShared(t *testing.T) {
+	shared := make([]MailboxMiddleware, 1, 4)
+	shared[0] = &recordingMiddleware{}
+	producer := UnboundedLockfree(shared...)
+
+	first, second := producer().(*defaultMailbox), producer().(*defaultMailbox)
+	firstStats, secondStats := &recordingMiddleware{}, &recordingMiddleware{}
+	first.use(firstStats)
+	second.use(secondStats)
+
+	assert.Same(t, firstStats, first.middlewares[1])
+	assert.Same(t, secondStats, second.middlewares[1])
+}
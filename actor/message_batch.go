@@ -0,0 +1,24 @@
+package actor
+
+// MessageBatch carries several envelopes to a local actor as a single mailbox entry, the actor receives them one by one
+// with their own sender and header, in order. The mailbox is scheduled once for the whole batch.
+//
+// When an envelope of the batch fails, the ones after it are sent to the dead letters.
+type MessageBatch struct {
+	Envelopes []*MessageEnvelope
+}
+
+// WrapBatch wraps envelopes in a MessageBatch envelope
+func WrapBatch(envelopes ...*MessageEnvelope) *MessageEnvelope {
+	return WrapEnvelope(&MessageBatch{Envelopes: envelopes})
+}
+
+// Add appends envelope to the batch
+func (b *MessageBatch) Add(envelope *MessageEnvelope) {
+	b.Envelopes = append(b.Envelopes, envelope)
+}
+
+// Len returns the number of envelopes in the batch
+func (b *MessageBatch) Len() int {
+	return len(b.Envelopes)
+}
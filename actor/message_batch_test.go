@@ -0,0 +1,73 @@
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageBatch_UnpackedInOrder(t *testing.T) {
+	type received struct {
+		message interface{}
+		sender  *PID
+		header  string
+	}
+
+	middleware := &recordingMiddleware{}
+	events := make(chan received, 10)
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Envelope().Message.(*tickMessage); ok {
+			events <- received{message: msg, sender: ctx.Sender(), header: ctx.Envelope().GetHeader("n")}
+		}
+	}, WithMailbox(UnboundedLockfree(middleware))))
+	defer rootContext.Stop(pid)
+
+	sender := system.NewLocalPID("batch-sender")
+	first := WrapEnvelopWithSender(&tickMessage{n: 1}, sender)
+	second := WrapEnvelope(&tickMessage{n: 2})
+	second.SetHeader("n", "2")
+	batch := WrapBatch(first, second)
+	batch.Message.(*MessageBatch).Add(WrapEnvelope(&tickMessage{n: 3}))
+	rootContext.Send(pid, batch)
+
+	assert.Equal(t, received{message: &tickMessage{n: 1}, sender: sender}, waitFor(t, events))
+	assert.Equal(t, received{message: &tickMessage{n: 2}, header: "2"}, waitFor(t, events))
+	assert.Equal(t, received{message: &tickMessage{n: 3}}, waitFor(t, events))
+
+	// Started system message and the batch
+	assert.Eventually(t, func() bool { return middleware.count("empty") > 0 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, middleware.count("posted"))
+}
+
+func TestMessageBatch_RestAfterPanicGoesToDeadLetters(t *testing.T) {
+	as := NewActorSystem()
+	events := make(chan int, 10)
+	pid := as.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Envelope().Message.(*tickMessage); ok {
+			if msg.n == 2 {
+				panic("tick failed")
+			}
+			events <- msg.n
+		}
+	}))
+	defer as.Root.Stop(pid)
+
+	as.Root.Send(pid, WrapBatch(
+		WrapEnvelope(&tickMessage{n: 1}),
+		WrapEnvelope(&tickMessage{n: 2}),
+		WrapEnvelope(&tickMessage{n: 3}),
+		WrapEnvelope(&tickMessage{n: 4}),
+	))
+	assert.Equal(t, 1, waitFor(t, events))
+
+	assert.Eventually(t, func() bool { return as.DeadLetter.Total() == 2 }, time.Second, time.Millisecond)
+	recent := as.DeadLetter.Recent()
+	assert.Equal(t, &tickMessage{n: 3}, recent[0].Message)
+	assert.Equal(t, &tickMessage{n: 4}, recent[1].Message)
+	assert.True(t, recent[0].PID.Equal(pid))
+
+	// the restarted actor keeps receiving
+	as.Root.Send(pid, WrapEnvelope(&tickMessage{n: 5}))
+	assert.Equal(t, 5, waitFor(t, events))
+}
@@ -35,10 +35,10 @@ type Restarting struct{}
 
 // Failure message is sent to an actor parent when an exception is thrown by one of its methods
 type Failure struct {
-	Who    *PID
-	Reason interface{}
-	//RestartStats *RestartStatistics
-	Message interface{}
+	Who          *PID
+	Reason       interface{}
+	RestartStats *RestartStatistics
+	Message      interface{}
 }
 
 // ResumeMailbox is message sent by the actor system to resume mailbox processing.
@@ -1,18 +1,96 @@
 package middleware
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
+
 	"github.com/colin1989/battery/actor"
-	"log"
-	"reflect"
 )
 
+type loggerConfig struct {
+	logger  *slog.Logger
+	level   slog.Level
+	message bool
+}
+
+// LoggerOption configures NewReceiveLogger and NewSendLogger
+type LoggerOption func(config *loggerConfig)
+
+// WithLogger logs with logger instead of the logger of the actor
+func WithLogger(logger *slog.Logger) LoggerOption {
+	return func(config *loggerConfig) {
+		config.logger = logger
+	}
+}
+
+// WithLogLevel sets the level of the records, slog.LevelInfo by default
+func WithLogLevel(level slog.Level) LoggerOption {
+	return func(config *loggerConfig) {
+		config.level = level
+	}
+}
+
+// WithLogMessage adds the message itself to the records, only its type is logged by default
+func WithLogMessage() LoggerOption {
+	return func(config *loggerConfig) {
+		config.message = true
+	}
+}
+
+func newLoggerConfig(opts ...LoggerOption) *loggerConfig {
+	config := &loggerConfig{level: slog.LevelInfo}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return config
+}
+
+func (config *loggerConfig) log(logger *slog.Logger, msg string, envelope *actor.MessageEnvelope, attrs ...slog.Attr) {
+	if config.logger != nil {
+		logger = config.logger
+	}
+	if !logger.Enabled(context.Background(), config.level) {
+		return
+	}
+
+	attrs = append(attrs, slog.String("type", fmt.Sprintf("%T", envelope.Message)))
+	if envelope.Sender != nil {
+		attrs = append(attrs, slog.String("sender", envelope.Sender.String()))
+	}
+	if config.message {
+		attrs = append(attrs, slog.Any("message", envelope.Message))
+	}
+	logger.LogAttrs(context.Background(), config.level, msg, attrs...)
+}
+
 // ReceiveLogger is message middleware which logs messages before continuing to the next middleware.
 func ReceiveLogger(next actor.ReceiverFunc) actor.ReceiverFunc {
-	fn := func(c actor.ReceiverContext, env *actor.MessageEnvelope) {
-		message := env.Message
-		log.Printf("%v got %v %+v", c.Self(), reflect.TypeOf(message), message)
-		next(c, env)
+	return NewReceiveLogger()(next)
+}
+
+// NewReceiveLogger logs every message received by the actor with its pid, the message type and the sender.
+func NewReceiveLogger(opts ...LoggerOption) actor.ReceiverMiddleware {
+	config := newLoggerConfig(opts...)
+
+	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+			config.log(c.Logger(), "actor received", envelope, slog.String("pid", c.Self().String()))
+			next(c, envelope)
+		}
 	}
+}
+
+// NewSendLogger logs every message sent by the actor with its pid, the target, the message type and the sender.
+func NewSendLogger(opts ...LoggerOption) actor.SenderMiddleware {
+	config := newLoggerConfig(opts...)
 
-	return fn
+	return func(next actor.SenderFunc) actor.SenderFunc {
+		return func(c actor.SenderContext, target *actor.PID, envelope *actor.MessageEnvelope) {
+			config.log(c.Logger(), "actor sent", envelope,
+				slog.String("pid", c.Self().String()), slog.String("target", target.String()))
+			next(c, target, envelope)
+		}
+	}
 }
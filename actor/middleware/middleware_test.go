@@ -0,0 +1,176 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/colin1989/battery/actor"
+	"github.com/colin1989/battery/actor/testkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ping struct{}
+
+type pong struct {
+	N int
+}
+
+type boom struct{}
+
+var errBoom = errors.New("boom")
+
+// counter answers every ping with the number of pings it received so far
+func counter(opts ...actor.PropsOption) *actor.Props {
+	n := 0
+	return actor.PropsFromFunc(func(ctx actor.Context) {
+		switch ctx.Envelope().Message.(type) {
+		case *ping:
+			n++
+			ctx.Respond(actor.WrapEnvelope(&pong{N: n}))
+		case *boom:
+			panic(errBoom)
+		}
+	}, opts...)
+}
+
+func TestReceiveLogger(t *testing.T) {
+	var logs bytes.Buffer
+	tk := testkit.New(t)
+	probe := tk.NewProbe()
+	pid := tk.Spawn(counter(actor.WithReceiverMiddleware(
+		NewReceiveLogger(WithLogger(slog.New(slog.NewTextHandler(&logs, nil)))),
+	)))
+
+	probe.Send(pid, &ping{})
+	probe.ExpectMsg(&pong{N: 1})
+
+	var line string
+	for _, l := range strings.Split(logs.String(), "\n") {
+		if strings.Contains(l, "type=*middleware.ping") {
+			line = l
+		}
+	}
+	require.NotEmpty(t, line, logs.String())
+	assert.Contains(t, line, `msg="actor received"`)
+	assert.Contains(t, line, "pid=")
+	assert.Contains(t, line, pid.ID)
+	assert.Contains(t, line, "sender=")
+	assert.Contains(t, line, probe.PID().ID)
+}
+
+func TestTiming(t *testing.T) {
+	timing := NewTiming(time.Millisecond, time.Second)
+	tk := testkit.New(t)
+	probe := tk.NewProbe()
+	pid := tk.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Envelope().Message.(*ping); ok {
+			tk.Advance(10 * time.Millisecond)
+			ctx.Respond(actor.WrapEnvelope(&pong{}))
+		}
+	}, actor.WithReceiverMiddleware(timing.ReceiverMiddleware)))
+
+	probe.Send(pid, &ping{})
+	probe.ExpectMsg(&pong{})
+	probe.Send(pid, &ping{})
+	probe.ExpectMsg(&pong{})
+
+	var stats TimingStats
+	for _, s := range timing.Stats() {
+		if s.MessageType == "*middleware.ping" {
+			stats = s
+		}
+	}
+	assert.Equal(t, uint64(2), stats.Count)
+	assert.Equal(t, 20*time.Millisecond, stats.Sum)
+	assert.Equal(t, 10*time.Millisecond, stats.Mean())
+	assert.Equal(t, 10*time.Millisecond, stats.Max)
+	assert.Equal(t, []uint64{0, 2, 0}, stats.Buckets)
+
+	timing.Reset()
+	assert.Empty(t, timing.Stats())
+}
+
+func TestRecover(t *testing.T) {
+	tk := testkit.New(t)
+	probe := tk.NewProbe()
+	pid := tk.Spawn(counter(actor.WithReceiverMiddleware(Recover(nil))))
+
+	probe.Send(pid, &ping{})
+	probe.ExpectMsg(&pong{N: 1})
+
+	probe.Send(pid, &boom{})
+	res := probe.FishForMessage(func(message interface{}) bool {
+		_, ok := message.(*PanicError)
+		return ok
+	})
+	err := res.Message.(*PanicError)
+	assert.True(t, errors.Is(err, errBoom))
+	assert.Equal(t, "*middleware.boom", err.MessageType)
+	assert.Contains(t, string(err.Stack), "recovery.go")
+
+	// the actor kept its state, it was not restarted
+	probe.Send(pid, &ping{})
+	probe.ExpectMsg(&pong{N: 2})
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(1, 2)
+	tk := testkit.New(t)
+	probe := tk.NewProbe()
+	pid := tk.Spawn(counter(actor.WithReceiverMiddleware(limiter.ReceiverMiddleware)))
+
+	for i := 0; i < 3; i++ {
+		probe.Send(pid, &ping{})
+	}
+	probe.ExpectMsg(&pong{N: 1})
+	probe.ExpectMsg(&pong{N: 2})
+	// the requester of the dropped message is told at once
+	probe.ExpectMsg(&actor.DeadLetterResponse{Target: pid})
+	assert.Equal(t, uint64(1), limiter.Dropped())
+	assert.Equal(t, uint64(1), tk.System.DeadLetter.Total())
+
+	tk.Advance(time.Second)
+	probe.Send(pid, &ping{})
+	probe.ExpectMsg(&pong{N: 3})
+}
+
+func TestRateLimiter_Close(t *testing.T) {
+	limiter := NewRateLimiter(1, 1)
+	tk := testkit.New(t)
+	probe := tk.NewProbe()
+	subscriptions := tk.System.EventStream.Length()
+	pid := tk.Spawn(counter(actor.WithReceiverMiddleware(limiter.ReceiverMiddleware)))
+
+	probe.Send(pid, &ping{})
+	probe.ExpectMsg(&pong{N: 1})
+	assert.Equal(t, subscriptions+1, tk.System.EventStream.Length())
+
+	limiter.Close()
+	assert.Equal(t, subscriptions, tk.System.EventStream.Length())
+
+	// the actor starts again with a full bucket
+	probe.Send(pid, &ping{})
+	probe.ExpectMsg(&pong{N: 2})
+}
+
+func TestRateLimiter_Sender(t *testing.T) {
+	limiter := NewRateLimiter(1, 1)
+	tk := testkit.New(t)
+	probe := tk.NewProbe()
+	pid := tk.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Envelope().Message.(*ping); ok {
+			ctx.Send(probe.PID(), actor.WrapEnvelope(&pong{}))
+		}
+	}, actor.WithSenderMiddleware(limiter.SenderMiddleware)))
+
+	tk.System.Root.Send(pid, actor.WrapEnvelope(&ping{}))
+	tk.System.Root.Send(pid, actor.WrapEnvelope(&ping{}))
+	probe.ExpectMsg(&pong{})
+	probe.ExpectNoMsg(10 * time.Millisecond)
+	assert.Equal(t, uint64(1), limiter.Dropped())
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/colin1989/battery/actor"
+)
+
+// RateLimiter limits the messages each actor receives or sends with a token bucket per actor.
+// A message beyond the limit goes to the dead letters, so a requester gets a DeadLetterResponse.
+// Lifecycle messages are never limited.
+// Call Close once the actor systems using it shut down.
+type RateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu      sync.Mutex
+	systems map[*actor.ActorSystem]*systemBuckets
+	dropped uint64
+}
+
+// systemBuckets are the buckets of the actors of a system, by PID ID
+type systemBuckets struct {
+	buckets map[string]*tokenBucket
+	// sub forgets the bucket of an actor once it stopped
+	sub *actor.Subscription
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter allows every actor rate messages per second on average, and up to burst at once.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		systems: make(map[*actor.ActorSystem]*systemBuckets),
+	}
+}
+
+// Close releases the EventStream subscriptions of the limiter and forgets its buckets.
+// Actors still using it start again with full buckets.
+func (l *RateLimiter) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for system, sb := range l.systems {
+		system.EventStream.Unsubscribe(sb.sub)
+		delete(l.systems, system)
+	}
+}
+
+// ReceiverMiddleware diverts the messages received beyond the limit to the dead letters.
+func (l *RateLimiter) ReceiverMiddleware(next actor.ReceiverFunc) actor.ReceiverFunc {
+	return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+		if isLifecycleMessage(envelope.Message) || l.allow(c.ActorSystem(), c.Self()) {
+			next(c, envelope)
+			return
+		}
+
+		atomic.AddUint64(&l.dropped, 1)
+		c.ActorSystem().DeadLetter.SendUserMessage(c.Self(), envelope)
+	}
+}
+
+// SenderMiddleware diverts the messages sent beyond the limit to the dead letters.
+func (l *RateLimiter) SenderMiddleware(next actor.SenderFunc) actor.SenderFunc {
+	return func(c actor.SenderContext, target *actor.PID, envelope *actor.MessageEnvelope) {
+		if c.Self() == nil || l.allow(c.ActorSystem(), c.Self()) {
+			next(c, target, envelope)
+			return
+		}
+
+		atomic.AddUint64(&l.dropped, 1)
+		c.ActorSystem().DeadLetter.SendUserMessage(target, envelope)
+	}
+}
+
+// Dropped returns the number of messages diverted to the dead letters
+func (l *RateLimiter) Dropped() uint64 {
+	return atomic.LoadUint64(&l.dropped)
+}
+
+func (l *RateLimiter) allow(system *actor.ActorSystem, pid *actor.PID) bool {
+	now := system.Config.Clock.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	sb, ok := l.systems[system]
+	if !ok {
+		sb = &systemBuckets{buckets: make(map[string]*tokenBucket)}
+		sb.sub = actor.SubscribeTo(system.EventStream, func(evt *actor.ActorStoppedEvent) {
+			l.mu.Lock()
+			delete(sb.buckets, evt.PID.ID)
+			l.mu.Unlock()
+		})
+		l.systems[system] = sb
+	}
+
+	bucket, ok := sb.buckets[pid.ID]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, last: now}
+		sb.buckets[pid.ID] = bucket
+	}
+
+	bucket.tokens = min(l.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate)
+	bucket.last = now
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+
+	return true
+}
+
+func isLifecycleMessage(message interface{}) bool {
+	switch message.(type) {
+	case actor.AutoReceiveMessage, actor.SystemMessage, *actor.Started, *actor.Stopping, *actor.Stopped,
+		*actor.Restarting, *actor.ReceiveTimeout, *actor.Terminated:
+		return true
+	default:
+		return false
+	}
+}
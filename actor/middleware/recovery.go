@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/colin1989/battery/actor"
+)
+
+// PanicError is a panic recovered by Recover while an actor received a message.
+type PanicError struct {
+	PID         *actor.PID
+	MessageType string
+	Reason      interface{}
+	Stack       []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("actor %s panicked receiving %s: %v", e.PID, e.MessageType, e.Reason)
+}
+
+// Unwrap returns the reason of the panic when it is an error
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Reason.(error); ok {
+		return err
+	}
+
+	return nil
+}
+
+// PanicHandler is called by Recover with the recovered panic, on the actor goroutine.
+type PanicHandler func(c actor.ReceiverContext, envelope *actor.MessageEnvelope, err *PanicError)
+
+// Recover turns a panic of the actor into a *PanicError, so the actor keeps its state and is not restarted
+// by its supervisor. handler defaults to LogAndRespond.
+func Recover(handler PanicHandler) actor.ReceiverMiddleware {
+	if handler == nil {
+		handler = LogAndRespond
+	}
+
+	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+			defer func() {
+				if r := recover(); r != nil {
+					handler(c, envelope, &PanicError{
+						PID:         c.Self(),
+						MessageType: fmt.Sprintf("%T", envelope.Message),
+						Reason:      r,
+						Stack:       debug.Stack(),
+					})
+				}
+			}()
+
+			next(c, envelope)
+		}
+	}
+}
+
+// LogAndRespond logs err with its stack and sends it to the sender of the message, if any,
+// so a request fails at once instead of timing out.
+func LogAndRespond(c actor.ReceiverContext, envelope *actor.MessageEnvelope, err *PanicError) {
+	c.Logger().Error("actor panic recovered",
+		slog.String("pid", err.PID.String()),
+		slog.String("type", err.MessageType),
+		slog.Any("err", err.Reason),
+		slog.String("stack", string(err.Stack)))
+
+	if envelope.Sender != nil {
+		c.ActorSystem().Root.Send(envelope.Sender, actor.WrapEnvelope(err))
+	}
+}
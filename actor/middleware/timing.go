@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/colin1989/battery/actor"
+)
+
+// DefaultTimingBuckets are the upper bounds of the histogram buckets used by NewTiming when none is given
+var DefaultTimingBuckets = []time.Duration{
+	100 * time.Microsecond,
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+}
+
+// TimingStats is the histogram of the time spent receiving one message type.
+type TimingStats struct {
+	MessageType string
+	Count       uint64
+	Sum         time.Duration
+	Max         time.Duration
+	// Bounds are the upper bounds of the buckets, Buckets[i] counts the messages which took at most Bounds[i]
+	// and the last bucket counts the ones slower than every bound.
+	Bounds  []time.Duration
+	Buckets []uint64
+}
+
+// Mean returns the average time spent per message
+func (s TimingStats) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+
+	return s.Sum / time.Duration(s.Count)
+}
+
+// Timing records how long the actors take to receive each message type, it is measured with the clock of the system.
+// A Timing can be shared by several props, the histograms are merged by message type.
+type Timing struct {
+	bounds []time.Duration
+	mu     sync.Mutex
+	stats  map[string]*TimingStats
+}
+
+// NewTiming creates a Timing with the given bucket upper bounds, DefaultTimingBuckets when none is given.
+func NewTiming(bounds ...time.Duration) *Timing {
+	if len(bounds) == 0 {
+		bounds = DefaultTimingBuckets
+	}
+	bounds = append([]time.Duration(nil), bounds...)
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i] < bounds[j] })
+
+	return &Timing{
+		bounds: bounds,
+		stats:  make(map[string]*TimingStats),
+	}
+}
+
+// ReceiverMiddleware times the rest of the receive chain, a panic is recorded before it propagates.
+func (t *Timing) ReceiverMiddleware(next actor.ReceiverFunc) actor.ReceiverFunc {
+	return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+		clock := c.ActorSystem().Config.Clock
+		start := clock.Now()
+		defer func() {
+			t.record(fmt.Sprintf("%T", envelope.Message), clock.Now().Sub(start))
+		}()
+
+		next(c, envelope)
+	}
+}
+
+func (t *Timing) record(messageType string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats, ok := t.stats[messageType]
+	if !ok {
+		stats = &TimingStats{
+			MessageType: messageType,
+			Bounds:      t.bounds,
+			Buckets:     make([]uint64, len(t.bounds)+1),
+		}
+		t.stats[messageType] = stats
+	}
+
+	stats.Count++
+	stats.Sum += d
+	stats.Max = max(stats.Max, d)
+	stats.Buckets[sort.Search(len(t.bounds), func(i int) bool { return d <= t.bounds[i] })]++
+}
+
+// Stats returns a copy of the histograms sorted by message type
+func (t *Timing) Stats() []TimingStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := make([]TimingStats, 0, len(t.stats))
+	for _, s := range t.stats {
+		copied := *s
+		copied.Buckets = append([]uint64(nil), s.Buckets...)
+		stats = append(stats, copied)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].MessageType < stats[j].MessageType })
+
+	return stats
+}
+
+// Reset clears the histograms
+func (t *Timing) Reset() {
+	t.mu.Lock()
+	t.stats = make(map[string]*TimingStats)
+	t.mu.Unlock()
+}
@@ -8,10 +8,16 @@ import (
 )
 
 var (
-	system      = NewActorSystem()
-	rootContext = system.Root
+	system      *ActorSystem
+	rootContext *RootContext
 )
 
+// the actor system is created in init so that the protobuf descriptors are registered before any PID is formatted.
+func init() {
+	system = NewActorSystem()
+	rootContext = system.Root
+}
+
 type mockContext struct {
 	mock.Mock
 }
@@ -77,7 +83,7 @@ func (m *mockContext) Send(_ *PID, _ *MessageEnvelope) {
 	m.Called()
 }
 
-func (m *mockContext) Request(pid *PID, envelope *MessageEnvelope) (*MessageEnvelope, error) {
+func (m *mockContext) Request(pid *PID, envelope *MessageEnvelope, _ ...RequestOption) (*MessageEnvelope, error) {
 	args := m.Called(pid, envelope)
 	return args.Get(0).(*MessageEnvelope), args.Get(0).(error)
 }
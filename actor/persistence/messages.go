@@ -0,0 +1,7 @@
+package persistence
+
+// RequestSnapshot is sent to the actor every snapshot interval, it should answer with PersistSnapshot
+type RequestSnapshot struct{}
+
+// ReplayComplete is sent to the actor once the snapshot and the events have been replayed
+type ReplayComplete struct{}
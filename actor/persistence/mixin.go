@@ -0,0 +1,124 @@
+package persistence
+
+import (
+	"log/slog"
+
+	"github.com/colin1989/battery/actor"
+)
+
+type persistent interface {
+	init(provider Provider, ctx actor.ReceiverContext) error
+}
+
+// Mixin makes an actor persistent when embedded in it and the actor is spawned with Using.
+//
+// On Started, including after a restart, the latest snapshot and the following events are passed to Receive
+// while Recovering is true, then ReplayComplete is sent. Live messages are persisted with PersistEvent.
+type Mixin struct {
+	provider   Provider
+	receiver   actor.ReceiverContext
+	name       string
+	eventIndex int
+	recovering bool
+}
+
+var _ persistent = &Mixin{}
+
+// Recovering reports whether the actor is replaying its journal
+func (m *Mixin) Recovering() bool {
+	return m.recovering
+}
+
+// Name returns the key of the actor journal, which is its PID ID
+func (m *Mixin) Name() string {
+	return m.name
+}
+
+// EventIndex returns the index the next persisted event gets
+func (m *Mixin) EventIndex() int {
+	return m.eventIndex
+}
+
+// PersistEvent appends event to the journal, the actor applies the event to its state first.
+// Every snapshot interval the actor receives RequestSnapshot once the event is stored.
+func (m *Mixin) PersistEvent(event interface{}) error {
+	if err := m.provider.PersistEvent(m.name, m.eventIndex, event); err != nil {
+		return err
+	}
+
+	m.eventIndex++
+	if interval := m.provider.GetSnapshotInterval(); interval > 0 && m.eventIndex%interval == 0 {
+		m.receiver.Receive(actor.WrapEnvelope(&RequestSnapshot{}))
+	}
+
+	return nil
+}
+
+// PersistSnapshot stores snapshot, replay starts from it and the events persisted afterwards.
+// Once the snapshot is stored the events it covers are deleted from the journal.
+func (m *Mixin) PersistSnapshot(snapshot interface{}) error {
+	if err := m.provider.PersistSnapshot(m.name, m.eventIndex, snapshot); err != nil {
+		return err
+	}
+	if m.eventIndex == 0 {
+		return nil
+	}
+
+	return m.provider.DeleteEvents(m.name, m.eventIndex-1)
+}
+
+func (m *Mixin) init(provider Provider, ctx actor.ReceiverContext) error {
+	m.provider = provider
+	m.receiver = ctx
+	m.name = ctx.Self().ID
+	m.eventIndex = 0
+	m.recovering = true
+	defer func() {
+		m.recovering = false
+	}()
+
+	snapshot, eventIndex, err := provider.GetSnapshot(m.name)
+	if err != nil {
+		return err
+	}
+	if snapshot != nil {
+		m.eventIndex = eventIndex
+		ctx.Receive(actor.WrapEnvelope(snapshot))
+	}
+
+	err = provider.GetEvents(m.name, m.eventIndex, func(event interface{}) {
+		ctx.Receive(actor.WrapEnvelope(event))
+		m.eventIndex++
+	})
+	if err != nil {
+		return err
+	}
+
+	m.recovering = false
+	ctx.Receive(actor.WrapEnvelope(&ReplayComplete{}))
+	return nil
+}
+
+// Using replays the journal of actors embedding Mixin when they start.
+// A journal that cannot be read makes the actor fail, so its supervisor decides what happens next.
+func Using(provider Provider) actor.ReceiverMiddleware {
+	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(ctx actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+			next(ctx, envelope)
+
+			if _, ok := envelope.Message.(*actor.Started); !ok {
+				return
+			}
+
+			p, ok := ctx.Actor().(persistent)
+			if !ok {
+				ctx.Logger().Error("actor is not persistent, embed persistence.Mixin", slog.Any("pid", ctx.Self()))
+				return
+			}
+
+			if err := p.init(provider, ctx); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
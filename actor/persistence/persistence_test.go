@@ -0,0 +1,246 @@
+package persistence
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/colin1989/battery/actor"
+	serialize "github.com/colin1989/battery/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type added struct {
+	Item string
+}
+
+type inventorySnapshot struct {
+	Items []string
+}
+
+type getItems struct{}
+
+type crash struct{}
+
+func init() {
+	serialize.RegisterType(&added{}, &inventorySnapshot{})
+}
+
+type inventory struct {
+	Mixin
+	items     []string
+	snapshots int
+	replayed  chan []string
+}
+
+func (a *inventory) Receive(ctx actor.Context) {
+	switch msg := ctx.Envelope().Message.(type) {
+	case *inventorySnapshot:
+		a.items = append([]string(nil), msg.Items...)
+	case *added:
+		a.items = append(a.items, msg.Item)
+		if a.Recovering() {
+			return
+		}
+		if err := a.PersistEvent(msg); err != nil {
+			panic(err)
+		}
+		ctx.Respond(actor.WrapEnvelope(len(a.items)))
+	case *RequestSnapshot:
+		a.snapshots++
+		if err := a.PersistSnapshot(&inventorySnapshot{Items: append([]string(nil), a.items...)}); err != nil {
+			panic(err)
+		}
+	case *ReplayComplete:
+		a.replayed <- append([]string(nil), a.items...)
+	case *getItems:
+		ctx.Respond(actor.WrapEnvelope(append([]string(nil), a.items...)))
+	case *crash:
+		panic(errors.New("crash"))
+	}
+}
+
+func spawnInventory(t *testing.T, system *actor.ActorSystem, provider Provider, replayed chan []string) *actor.PID {
+	t.Helper()
+	props := actor.PropsFromProducer(func() actor.Actor {
+		return &inventory{replayed: replayed}
+	}, actor.WithReceiverMiddleware(Using(provider)))
+
+	pid, err := system.Root.SpawnNamed(props, "inventory")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = system.Root.StopFuture(pid).Wait() })
+
+	return pid
+}
+
+func waitReplay(t *testing.T, replayed chan []string) []string {
+	t.Helper()
+	select {
+	case items := <-replayed:
+		return items
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for replay")
+	}
+
+	return nil
+}
+
+func add(t *testing.T, system *actor.ActorSystem, pid *actor.PID, items ...string) {
+	t.Helper()
+	for _, item := range items {
+		res, err := system.Root.Request(pid, actor.WrapEnvelope(&added{Item: item}))
+		require.NoError(t, err)
+		require.IsType(t, 0, res.Message)
+	}
+}
+
+func TestMixin_ReplayAfterRestart(t *testing.T) {
+	system := actor.NewActorSystem()
+	provider := NewInMemoryProvider(2)
+	replayed := make(chan []string, 1)
+	pid := spawnInventory(t, system, provider, replayed)
+	assert.Empty(t, waitReplay(t, replayed))
+
+	add(t, system, pid, "sword", "shield", "potion")
+	snapshot, index, err := provider.GetSnapshot(pid.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &inventorySnapshot{Items: []string{"sword", "shield"}}, snapshot)
+	assert.Equal(t, 2, index)
+
+	system.Root.Send(pid, actor.WrapEnvelope(&crash{}))
+	assert.Equal(t, []string{"sword", "shield", "potion"}, waitReplay(t, replayed))
+
+	add(t, system, pid, "bow")
+	res, err := system.Root.Request(pid, actor.WrapEnvelope(&getItems{}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sword", "shield", "potion", "bow"}, res.Message)
+}
+
+func TestFileProvider_SurvivesProcessRestart(t *testing.T) {
+	dir := t.TempDir()
+	provider, err := NewFileProvider(dir, 2)
+	require.NoError(t, err)
+
+	system := actor.NewActorSystem()
+	replayed := make(chan []string, 1)
+	pid := spawnInventory(t, system, provider, replayed)
+	waitReplay(t, replayed)
+	add(t, system, pid, "sword", "shield", "potion")
+	require.NoError(t, system.Root.StopFuture(pid).Wait())
+	system.Shutdown()
+
+	provider, err = NewFileProvider(dir, 2)
+	require.NoError(t, err)
+	spawnInventory(t, actor.NewActorSystem(), provider, replayed)
+	assert.Equal(t, []string{"sword", "shield", "potion"}, waitReplay(t, replayed))
+}
+
+func TestFileProvider_Journal(t *testing.T) {
+	dir := t.TempDir()
+	provider, err := NewFileProvider(dir, 0)
+	require.NoError(t, err)
+
+	for i, item := range []string{"a", "b", "c"} {
+		require.NoError(t, provider.PersistEvent("player/1", i, &added{Item: item}))
+	}
+	require.NoError(t, provider.DeleteEvents("player/1", 0))
+
+	// a crash while appending leaves a torn last line
+	f, err := os.OpenFile(filepath.Join(dir, "player%2F1.journal"), os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"index":3,"ty`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	var events []interface{}
+	require.NoError(t, provider.GetEvents("player/1", 0, func(event interface{}) {
+		events = append(events, event)
+	}))
+	assert.Equal(t, []interface{}{&added{Item: "b"}, &added{Item: "c"}}, events)
+
+	// the next process recovers, then appends after the torn line
+	provider, err = NewFileProvider(dir, 0)
+	require.NoError(t, err)
+	require.NoError(t, provider.GetEvents("player/1", 1, func(event interface{}) {}))
+	require.NoError(t, provider.PersistEvent("player/1", 3, &added{Item: "d"}))
+	events = nil
+	require.NoError(t, provider.GetEvents("player/1", 0, func(event interface{}) {
+		events = append(events, event)
+	}))
+	assert.Equal(t, []interface{}{&added{Item: "b"}, &added{Item: "c"}, &added{Item: "d"}}, events)
+
+	snapshot, _, err := provider.GetSnapshot("player/1")
+	require.NoError(t, err)
+	assert.Nil(t, snapshot)
+}
+
+// providers returns a constructor of every Provider, the providers must behave the same
+func providers() map[string]func(t *testing.T) Provider {
+	return map[string]func(t *testing.T) Provider{
+		"in memory": func(t *testing.T) Provider {
+			return NewInMemoryProvider(2)
+		},
+		"file": func(t *testing.T) Provider {
+			provider, err := NewFileProvider(t.TempDir(), 2)
+			require.NoError(t, err)
+			return provider
+		},
+	}
+}
+
+func TestProvider_PersistEventAfterRewind(t *testing.T) {
+	for name, newProvider := range providers() {
+		t.Run(name, func(t *testing.T) {
+			provider := newProvider(t)
+			events := func() []interface{} {
+				var events []interface{}
+				require.NoError(t, provider.GetEvents("player", 0, func(event interface{}) {
+					events = append(events, event)
+				}))
+				return events
+			}
+
+			for i, item := range []string{"a", "b", "c"} {
+				require.NoError(t, provider.PersistEvent("player", i, &added{Item: item}))
+			}
+
+			// persisting an index again replaces it and drops the events after it
+			require.NoError(t, provider.PersistEvent("player", 1, &added{Item: "x"}))
+			assert.Equal(t, []interface{}{&added{Item: "a"}, &added{Item: "x"}}, events())
+
+			require.NoError(t, provider.PersistEvent("player", 2, &added{Item: "y"}))
+			assert.Equal(t, []interface{}{&added{Item: "a"}, &added{Item: "x"}, &added{Item: "y"}}, events())
+
+			// a rewind behind the deleted events keeps none of the journal
+			require.NoError(t, provider.DeleteEvents("player", 1))
+			require.NoError(t, provider.PersistEvent("player", 0, &added{Item: "z"}))
+			assert.Equal(t, []interface{}{&added{Item: "z"}}, events())
+		})
+	}
+}
+
+func TestMixin_SnapshotCompactsJournal(t *testing.T) {
+	for name, newProvider := range providers() {
+		t.Run(name, func(t *testing.T) {
+			provider := newProvider(t)
+			system := actor.NewActorSystem()
+			replayed := make(chan []string, 1)
+			pid := spawnInventory(t, system, provider, replayed)
+			waitReplay(t, replayed)
+			add(t, system, pid, "sword", "shield", "potion", "bow", "arrow")
+
+			// the snapshot taken at index 4 covers the first four events
+			var events []interface{}
+			require.NoError(t, provider.GetEvents(pid.ID, 0, func(event interface{}) {
+				events = append(events, event)
+			}))
+			assert.Equal(t, []interface{}{&added{Item: "arrow"}}, events)
+
+			system.Root.Send(pid, actor.WrapEnvelope(&crash{}))
+			assert.Equal(t, []string{"sword", "shield", "potion", "bow", "arrow"}, waitReplay(t, replayed))
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package persistence
+
+// Provider stores the events and snapshots of persistent actors, keyed by actor name.
+// Event indexes start at 0 and grow by one for every persisted event.
+type Provider interface {
+	// GetSnapshotInterval returns after how many events the actor is asked for a snapshot, 0 disables snapshots
+	GetSnapshotInterval() int
+
+	// GetSnapshot returns the latest snapshot and the index of the first event not contained in it,
+	// snapshot is nil when the actor has none
+	GetSnapshot(actorName string) (snapshot interface{}, eventIndex int, err error)
+
+	PersistSnapshot(actorName string, eventIndex int, snapshot interface{}) error
+
+	// GetEvents calls callback for every event starting at eventIndexStart, in order
+	GetEvents(actorName string, eventIndexStart int, callback func(event interface{})) error
+
+	PersistEvent(actorName string, eventIndex int, event interface{}) error
+
+	// DeleteEvents removes the events up to inclusiveToIndex, e.g. once they are covered by a snapshot
+	DeleteEvents(actorName string, inclusiveToIndex int) error
+}
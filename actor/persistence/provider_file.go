@@ -0,0 +1,288 @@
+package persistence
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	serialize "github.com/colin1989/battery/serializer"
+)
+
+// FileProvider stores every actor in an append-only journal file and a snapshot file inside a directory.
+// Events and snapshots must be protobuf messages or types registered with serialize.RegisterType.
+type FileProvider struct {
+	mu               sync.Mutex
+	dir              string
+	snapshotInterval int
+	next             map[string]int // index following the last event of the journals recovered or written
+}
+
+var _ Provider = &FileProvider{}
+
+// record is a line of a journal, or the content of a snapshot file
+type record struct {
+	Index      int    `json:"index"`
+	Type       string `json:"type"`
+	Serializer byte   `json:"serializer"`
+	Data       []byte `json:"data"`
+}
+
+func NewFileProvider(dir string, snapshotInterval int) (*FileProvider, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &FileProvider{
+		dir:              dir,
+		snapshotInterval: snapshotInterval,
+		next:             make(map[string]int),
+	}, nil
+}
+
+func (p *FileProvider) GetSnapshotInterval() int {
+	return p.snapshotInterval
+}
+
+func (p *FileProvider) GetSnapshot(actorName string) (interface{}, int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	data, err := os.ReadFile(p.path(actorName, ".snapshot"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var r record
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, 0, fmt.Errorf("persistence: invalid snapshot of %s: %w", actorName, err)
+	}
+
+	snapshot, err := serialize.Deserialize(r.Data, r.Type, r.Serializer)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return snapshot, r.Index, nil
+}
+
+func (p *FileProvider) PersistSnapshot(actorName string, eventIndex int, snapshot interface{}) error {
+	data, err := encodeRecord(eventIndex, snapshot)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return writeFile(p.path(actorName, ".snapshot"), data)
+}
+
+func (p *FileProvider) GetEvents(actorName string, eventIndexStart int, callback func(event interface{})) error {
+	p.mu.Lock()
+	records, err := p.recoverJournal(actorName)
+	p.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		if r.Index < eventIndexStart {
+			continue
+		}
+		event, err := serialize.Deserialize(r.Data, r.Type, r.Serializer)
+		if err != nil {
+			return err
+		}
+		callback(event)
+	}
+
+	return nil
+}
+
+func (p *FileProvider) PersistEvent(actorName string, eventIndex int, event interface{}) error {
+	data, err := encodeRecord(eventIndex, event)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// an index behind the journal end means the actor replayed from an older state, drop what follows
+	if next, ok := p.next[actorName]; ok && eventIndex < next {
+		if err := p.truncateJournal(actorName, eventIndex); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(p.path(actorName, ".journal"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(append(data, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		delete(p.next, actorName)
+		return err
+	}
+	p.next[actorName] = eventIndex + 1
+
+	return nil
+}
+
+func (p *FileProvider) DeleteEvents(actorName string, inclusiveToIndex int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	records, _, err := p.readJournal(actorName, inclusiveToIndex+1)
+	if err != nil {
+		return err
+	}
+
+	return p.writeJournal(actorName, records)
+}
+
+// recoverJournal returns every record of the journal and remembers where it ends.
+// A torn last line is removed, so the next append starts on a line of its own.
+func (p *FileProvider) recoverJournal(actorName string) ([]*record, error) {
+	records, torn, err := p.readJournal(actorName, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if torn {
+		if err := p.writeJournal(actorName, records); err != nil {
+			return nil, err
+		}
+	}
+
+	next := 0
+	if len(records) > 0 {
+		next = records[len(records)-1].Index + 1
+	}
+	p.next[actorName] = next
+
+	return records, nil
+}
+
+// truncateJournal removes the events from index on
+func (p *FileProvider) truncateJournal(actorName string, index int) error {
+	records, _, err := p.readJournal(actorName, 0)
+	if err != nil {
+		return err
+	}
+
+	for i, r := range records {
+		if r.Index >= index {
+			records = records[:i]
+			break
+		}
+	}
+
+	return p.writeJournal(actorName, records)
+}
+
+func (p *FileProvider) writeJournal(actorName string, records []*record) error {
+	var buf bytes.Buffer
+	for _, r := range records {
+		data, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	return writeFile(p.path(actorName, ".journal"), buf.Bytes())
+}
+
+// readJournal returns the records of the journal from index on, and whether the journal ends with a torn line
+func (p *FileProvider) readJournal(actorName string, from int) ([]*record, bool, error) {
+	f, err := os.Open(p.path(actorName, ".journal"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	defer f.Close()
+
+	var records []*record
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			r := &record{}
+			if err := json.Unmarshal(line, r); err != nil {
+				// a torn last line is left by a crash in the middle of a write
+				if line[len(line)-1] != '\n' {
+					return records, true, nil
+				}
+				return nil, false, fmt.Errorf("persistence: invalid journal of %s: %w", actorName, err)
+			}
+			if r.Index >= from {
+				records = append(records, r)
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	return records, false, nil
+}
+
+// path escapes actorName, PID IDs contain "/" for children
+func (p *FileProvider) path(actorName, ext string) string {
+	return filepath.Join(p.dir, url.PathEscape(actorName)+ext)
+}
+
+func encodeRecord(index int, msg interface{}) ([]byte, error) {
+	data, typeName, serializerID, err := serialize.Serialize(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&record{
+		Index:      index,
+		Type:       typeName,
+		Serializer: serializerID,
+		Data:       data,
+	})
+}
+
+// writeFile replaces path atomically
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,102 @@
+package persistence
+
+import "sync"
+
+type snapshotEntry struct {
+	eventIndex int
+	snapshot   interface{}
+}
+
+// InMemoryProvider keeps the journals in memory, it survives actor restarts but not the process.
+type InMemoryProvider struct {
+	mu               sync.RWMutex
+	snapshotInterval int
+	snapshots        map[string]*snapshotEntry
+	events           map[string][]interface{}
+	offsets          map[string]int
+}
+
+var _ Provider = &InMemoryProvider{}
+
+func NewInMemoryProvider(snapshotInterval int) *InMemoryProvider {
+	return &InMemoryProvider{
+		snapshotInterval: snapshotInterval,
+		snapshots:        make(map[string]*snapshotEntry),
+		events:           make(map[string][]interface{}),
+		offsets:          make(map[string]int),
+	}
+}
+
+func (p *InMemoryProvider) GetSnapshotInterval() int {
+	return p.snapshotInterval
+}
+
+func (p *InMemoryProvider) GetSnapshot(actorName string) (interface{}, int, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	entry, ok := p.snapshots[actorName]
+	if !ok {
+		return nil, 0, nil
+	}
+
+	return entry.snapshot, entry.eventIndex, nil
+}
+
+func (p *InMemoryProvider) PersistSnapshot(actorName string, eventIndex int, snapshot interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.snapshots[actorName] = &snapshotEntry{eventIndex: eventIndex, snapshot: snapshot}
+	return nil
+}
+
+func (p *InMemoryProvider) GetEvents(actorName string, eventIndexStart int, callback func(event interface{})) error {
+	p.mu.RLock()
+	offset := p.offsets[actorName]
+	events := p.events[actorName]
+	p.mu.RUnlock()
+
+	for i := eventIndexStart - offset; i < len(events); i++ {
+		if i >= 0 {
+			callback(events[i])
+		}
+	}
+
+	return nil
+}
+
+func (p *InMemoryProvider) PersistEvent(actorName string, eventIndex int, event interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// an index behind the journal end means the actor replayed from an older state, drop what follows
+	events := p.events[actorName]
+	if n := eventIndex - p.offsets[actorName]; n < 0 {
+		events = nil
+		p.offsets[actorName] = eventIndex
+	} else if n < len(events) {
+		events = events[:n]
+	}
+	p.events[actorName] = append(events, event)
+	return nil
+}
+
+func (p *InMemoryProvider) DeleteEvents(actorName string, inclusiveToIndex int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	offset := p.offsets[actorName]
+	n := inclusiveToIndex + 1 - offset
+	events := p.events[actorName]
+	if n <= 0 {
+		return nil
+	}
+	if n > len(events) {
+		n = len(events)
+	}
+
+	p.events[actorName] = append([]interface{}(nil), events[n:]...)
+	p.offsets[actorName] = offset + n
+	return nil
+}
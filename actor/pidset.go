@@ -7,12 +7,13 @@ type PIDSet struct {
 
 // pidKey is used as a key in the lookup map to avoid allocations.
 type pidKey struct {
-	address string
-	id      string
+	address   string
+	id        string
+	requestId uint32
 }
 
 func (p *PIDSet) key(pid *PID) pidKey {
-	return pidKey{address: pid.Address, id: pid.ID}
+	return pidKey{address: pid.Address, id: pid.ID, requestId: pid.RequestId}
 }
 
 // NewPIDSet returns a new PIDSet with the given pids.
@@ -0,0 +1,106 @@
+package actor
+
+import (
+	"strconv"
+
+	"github.com/colin1989/battery/queue/goring"
+	"github.com/colin1989/battery/queue/mpsc"
+)
+
+const (
+	priorities = 8
+
+	// LowestPriority is processed after every other user message
+	LowestPriority int8 = 0
+
+	// DefaultPriority is used for messages that carry no priority
+	DefaultPriority int8 = priorities / 2
+
+	// HighestPriority is processed before every other user message
+	HighestPriority int8 = priorities - 1
+
+	// PriorityHeader is the MessageEnvelope header carrying the priority of a single send, e.g. "7".
+	// It takes precedence over PriorityMessage.
+	PriorityHeader = "priority"
+)
+
+// PriorityMessage is implemented by user messages that should jump ahead in a priority mailbox.
+// Higher values are processed first, values are clamped to [LowestPriority, HighestPriority].
+type PriorityMessage interface {
+	GetPriority() int8
+}
+
+// UnboundedPriority returns a producer which creates an unbounded mailbox ordering user messages by priority.
+// Messages of the same priority keep their FIFO order, system messages are still processed before any user message.
+func UnboundedPriority(mailboxStats ...MailboxMiddleware) MailboxProducer {
+	return func() Mailbox {
+		return &defaultMailbox{
+			userMailbox: newPriorityQueue(func() queue[*MessageEnvelope] {
+				return goring.New[*MessageEnvelope](10)
+			}),
+			systemMailbox: mpsc.New[SystemMessage](),
+			middlewares:   mailboxStats,
+		}
+	}
+}
+
+type priorityQueue struct {
+	priorityQueues []queue[*MessageEnvelope]
+}
+
+var _ queue[*MessageEnvelope] = &priorityQueue{}
+
+func newPriorityQueue(queueProducer func() queue[*MessageEnvelope]) *priorityQueue {
+	q := &priorityQueue{
+		priorityQueues: make([]queue[*MessageEnvelope], priorities),
+	}
+
+	for p := 0; p < priorities; p++ {
+		q.priorityQueues[p] = queueProducer()
+	}
+
+	return q
+}
+
+func (q *priorityQueue) Push(envelope *MessageEnvelope) {
+	q.priorityQueues[envelopePriority(envelope)].Push(envelope)
+}
+
+func (q *priorityQueue) Pop() (*MessageEnvelope, bool) {
+	for p := priorities - 1; p >= 0; p-- {
+		if envelope, ok := q.priorityQueues[p].Pop(); ok {
+			return envelope, true
+		}
+	}
+
+	return nil, false
+}
+
+func envelopePriority(envelope *MessageEnvelope) int8 {
+	if envelope == nil {
+		return DefaultPriority
+	}
+
+	if value := envelope.GetHeader(PriorityHeader); value != "" {
+		if p, err := strconv.Atoi(value); err == nil {
+			return clampPriority(p)
+		}
+	}
+
+	if pm, ok := envelope.Message.(PriorityMessage); ok {
+		return clampPriority(int(pm.GetPriority()))
+	}
+
+	return DefaultPriority
+}
+
+func clampPriority(p int) int8 {
+	if p < int(LowestPriority) {
+		return LowestPriority
+	}
+	if p > int(HighestPriority) {
+		return HighestPriority
+	}
+
+	return int8(p)
+}
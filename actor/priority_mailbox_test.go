@@ -0,0 +1,70 @@
+package actor
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/colin1989/battery/queue/goring"
+	"github.com/stretchr/testify/assert"
+)
+
+type kickMessage struct{}
+
+func (*kickMessage) GetPriority() int8 { return HighestPriority }
+
+func newTestPriorityQueue() *priorityQueue {
+	return newPriorityQueue(func() queue[*MessageEnvelope] {
+		return goring.New[*MessageEnvelope](10)
+	})
+}
+
+func TestPriorityQueue_PopsHighestFirst(t *testing.T) {
+	q := newTestPriorityQueue()
+
+	q.Push(WrapEnvelope("normal-1"))
+	q.Push(WrapEnvelope(&kickMessage{}))
+	q.Push(WrapEnvelope("normal-2"))
+
+	low := WrapEnvelope("low")
+	low.SetHeader(PriorityHeader, strconv.Itoa(int(LowestPriority)))
+	q.Push(low)
+
+	var order []interface{}
+	for {
+		envelope, ok := q.Pop()
+		if !ok {
+			break
+		}
+		order = append(order, envelope.Message)
+	}
+
+	assert.Equal(t, []interface{}{&kickMessage{}, "normal-1", "normal-2", "low"}, order)
+}
+
+func TestPriorityQueue_HeaderOverridesMessage(t *testing.T) {
+	envelope := WrapEnvelope(&kickMessage{})
+	envelope.SetHeader(PriorityHeader, "1")
+	assert.Equal(t, int8(1), envelopePriority(envelope))
+
+	envelope.SetHeader(PriorityHeader, "100")
+	assert.Equal(t, HighestPriority, envelopePriority(envelope))
+
+	envelope.SetHeader(PriorityHeader, "invalid")
+	assert.Equal(t, HighestPriority, envelopePriority(envelope))
+
+	assert.Equal(t, DefaultPriority, envelopePriority(WrapEnvelope("plain")))
+}
+
+func TestPriorityMailbox_ProcessesUrgentMessageFirst(t *testing.T) {
+	pid, release, received := spawnBlockedActor(t, UnboundedPriority())
+	defer rootContext.Stop(pid)
+
+	rootContext.Send(pid, WrapEnvelope(1))
+	rootContext.Send(pid, WrapEnvelope(2))
+	urgent := WrapEnvelope(3)
+	urgent.SetHeader(PriorityHeader, strconv.Itoa(int(HighestPriority)))
+	rootContext.Send(pid, urgent)
+	close(release)
+
+	assert.Equal(t, []interface{}{3, 1, 2}, drain(received, 3))
+}
@@ -1,6 +1,7 @@
 package actor
 
 import (
+	"context"
 	"log/slog"
 	"sync"
 	"sync/atomic"
@@ -13,12 +14,13 @@ import (
 //
 //	@Description: 存储管理actor
 type ProcessRegistry struct {
-	SequenceID  uint64
-	ActorSystem *ActorSystem
-	Address     string
-	LocalPIDs   *SliceMap
-	//RemoteHandlers []AddressResolver
-	wg sync.WaitGroup
+	SequenceID     uint64
+	ActorSystem    *ActorSystem
+	Address        string
+	LocalPIDs      *SliceMap
+	RemoteHandlers []AddressResolver
+	wg             sync.WaitGroup
+	topLevel       sync.Map // id -> *PID of the processes spawned by the root context
 }
 
 type SliceMap struct {
@@ -56,12 +58,18 @@ func NewProcessRegistry(actorSystem *ActorSystem) *ProcessRegistry {
 	}
 }
 
-//// An AddressResolver is used to resolve remote actors
-//type AddressResolver func(*PID) (Process, bool)
-//
-//func (pr *ProcessRegistry) RegisterAddressResolver(handler AddressResolver) {
-//	pr.RemoteHandlers = append(pr.RemoteHandlers, handler)
-//}
+// An AddressResolver is used to resolve remote actors
+type AddressResolver func(*PID) (Process, bool)
+
+func (pr *ProcessRegistry) RegisterAddressResolver(handler AddressResolver) {
+	pr.RemoteHandlers = append(pr.RemoteHandlers, handler)
+}
+
+// IsLocal reports whether the pid lives in this actor system.
+// PIDs created before a remote address was assigned keep the "nonhost" address and are still local.
+func (pr *ProcessRegistry) IsLocal(pid *PID) bool {
+	return pid.Address == localAddress || pid.Address == pr.Address
+}
 
 const (
 	digits = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ~+"
@@ -110,10 +118,14 @@ func (pr *ProcessRegistry) Add(process Process, id string) (*PID, bool) {
 func (pr *ProcessRegistry) Remove(pid *PID) {
 	bucket := pr.LocalPIDs.GetBucket(pid.ID)
 
-	ref, _ := bucket.Pop(pid.ID)
+	ref, exists := bucket.Pop(pid.ID)
+	if !exists {
+		return
+	}
 	if l, ok := ref.(*ActorProcess); ok {
 		atomic.StoreInt32(&l.dead, 1)
 	}
+	pr.topLevel.Delete(pid.ID)
 	pr.wg.Done()
 	pr.ActorSystem.Logger().Debug("Remove PID", slog.String("pid", pid.String()))
 }
@@ -123,7 +135,16 @@ func (pr *ProcessRegistry) Get(pid *PID) (Process, bool) {
 		return pr.ActorSystem.DeadLetter, false
 	}
 
-	// TODO Get Remote Process
+	if !pr.IsLocal(pid) {
+		for _, handler := range pr.RemoteHandlers {
+			ref, ok := handler(pid)
+			if ok {
+				return ref, true
+			}
+		}
+
+		return pr.ActorSystem.DeadLetter, false
+	}
 
 	bucket := pr.LocalPIDs.GetBucket(pid.ID)
 	ref, ok := bucket.Get(pid.ID)
@@ -134,6 +155,68 @@ func (pr *ProcessRegistry) Get(pid *PID) (Process, bool) {
 	return p, ok
 }
 
+// PIDs returns the local actors, futures and other internal processes are left out.
+func (pr *ProcessRegistry) PIDs() []*PID {
+	var pids []*PID
+	for _, bucket := range pr.LocalPIDs.LocalPIDs {
+		bucket.IterCb(func(id string, v interface{}) {
+			if _, ok := v.(*ActorProcess); ok {
+				pids = append(pids, NewPID(pr.Address, id))
+			}
+		})
+	}
+
+	return pids
+}
+
+// processPIDs returns every local process, routers and futures included.
+func (pr *ProcessRegistry) processPIDs() []*PID {
+	var pids []*PID
+	for _, bucket := range pr.LocalPIDs.LocalPIDs {
+		bucket.IterCb(func(id string, v interface{}) {
+			pids = append(pids, NewPID(pr.Address, id))
+		})
+	}
+
+	return pids
+}
+
+// addTopLevel records a process spawned by the root context.
+func (pr *ProcessRegistry) addTopLevel(pid *PID) {
+	pr.topLevel.Store(pid.ID, pid)
+	// it may have stopped before it was recorded
+	if _, ok := pr.LocalPIDs.GetBucket(pid.ID).Get(pid.ID); !ok {
+		pr.topLevel.Delete(pid.ID)
+	}
+}
+
+// topLevelPIDs returns the registered processes spawned by the root context, of any kind.
+func (pr *ProcessRegistry) topLevelPIDs() []*PID {
+	var pids []*PID
+	pr.topLevel.Range(func(_, v interface{}) bool {
+		pids = append(pids, v.(*PID))
+		return true
+	})
+
+	return pids
+}
+
+// wait blocks until every process is removed or ctx is done, it reports whether all were removed.
+func (pr *ProcessRegistry) wait(ctx context.Context) bool {
+	done := make(chan struct{})
+	go func() {
+		pr.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
 func (pr *ProcessRegistry) shutdown() {
 	//for k, d := range pr.LocalPIDs.LocalPIDs {
 	//	fmt.Printf("key : %v, count : %d \n", k, d.Count())
@@ -9,15 +9,29 @@ var (
 		mb := props.produceMailbox()
 
 		dp := props.getDispatcher()
+		if pinned, ok := dp.(pinnedDispatcher); ok {
+			ctx.pinned = pinned.pin()
+			dp = ctx.pinned
+		}
 		proc := NewActorProcess(mb)
+		proc.ctx = ctx
 		pid, absent := actorSystem.ProcessRegistry.Add(proc, id)
 		if !absent {
+			if ctx.pinned != nil {
+				ctx.pinned.stop()
+			}
 			return pid, ErrNameExists
 		}
 		ctx.self = pid
 
+		if props.mailboxStats {
+			actorSystem.mailboxStats.register(pid, mb, actorSystem.Config.Clock)
+		}
+
 		initialize(props, ctx)
 
+		actorSystem.EventStream.Publish(&ActorSpawnedEvent{PID: pid, Parent: ctx.parent})
+
 		mb.RegisterHandlers(ctx, dp)
 		mb.PostSystemMessage(startedMessage)
 		mb.Start()
@@ -44,6 +58,9 @@ type Props struct {
 	producer                ProducerWithActorSystem
 	mailboxProducer         MailboxProducer
 	dispatcher              Dispatcher
+	supervisionStrategy     SupervisorStrategy
+	mailboxStats            bool
+	stashCapacity           int
 	receiverMiddleware      []ReceiverMiddleware
 	senderMiddleware        []SenderMiddleware
 	spawnMiddleware         []SpawnMiddleware
@@ -70,6 +87,14 @@ func (props *Props) getDispatcher() Dispatcher {
 	return props.dispatcher
 }
 
+func (props *Props) getSupervisor() SupervisorStrategy {
+	if props.supervisionStrategy == nil {
+		return defaultSupervisionStrategy
+	}
+
+	return props.supervisionStrategy
+}
+
 func (props *Props) produceMailbox() Mailbox {
 	if props.mailboxProducer == nil {
 		return defaultMailboxProducer()
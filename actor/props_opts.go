@@ -74,8 +74,38 @@ func WithFunc(f ReceiveFunc) PropsOption {
 	}
 }
 
+// WithSupervisor sets the strategy used to supervise the children spawned by this actor.
+func WithSupervisor(supervisor SupervisorStrategy) PropsOption {
+	return func(props *Props) {
+		props.supervisionStrategy = supervisor
+	}
+}
+
+// WithDispatcher sets the dispatcher running the mailboxes of the actors spawned from these props.
+func WithDispatcher(dispatcher Dispatcher) PropsOption {
+	return func(props *Props) {
+		props.dispatcher = dispatcher
+	}
+}
+
 func WithMailbox(mailbox MailboxProducer) PropsOption {
 	return func(props *Props) {
 		props.mailboxProducer = mailbox
 	}
 }
+
+// WithMailboxStats collects queue depth, latency, throughput and invoke time for every actor spawned from these props.
+// The statistics are queried with ActorSystem.MailboxStats.
+func WithMailboxStats() PropsOption {
+	return func(props *Props) {
+		props.mailboxStats = true
+	}
+}
+
+// WithStashCapacity bounds the number of messages an actor can stash, Context.Stash fails with ErrStashFull beyond.
+// The stash is unbounded by default.
+func WithStashCapacity(capacity int) PropsOption {
+	return func(props *Props) {
+		props.stashCapacity = capacity
+	}
+}
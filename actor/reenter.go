@@ -0,0 +1,46 @@
+package actor
+
+// reenterContinuation is posted to the actor mailbox by ReenterAfter once the awaited future completed.
+type reenterContinuation struct {
+	envelope   *MessageEnvelope
+	stash      bool
+	generation int // incarnation calling ReenterAfter, see actorContextExtras.reenterGeneration
+	f          func()
+}
+
+func (*reenterContinuation) SystemMessage() {}
+
+type reenterConfig struct {
+	stash bool
+}
+
+// ReenterOption configures ReenterAfter
+type ReenterOption func(config *reenterConfig)
+
+// WithReenterStash keeps incoming user messages aside until the continuation ran, and then replays them in order.
+// Use it when the actor must not observe other messages while waiting, e.g. a player actor loading its state.
+func WithReenterStash() ReenterOption {
+	return func(config *reenterConfig) {
+		config.stash = true
+	}
+}
+
+func newReenterConfig(opts ...ReenterOption) *reenterConfig {
+	config := &reenterConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return config
+}
+
+// isStashable reports whether a user message may be held back by a stashing ReenterAfter.
+// Lifecycle messages are always delivered.
+func isStashable(envelope *MessageEnvelope) bool {
+	switch envelope.Message.(type) {
+	case AutoReceiveMessage, *Started, *Terminated, *ReceiveTimeout:
+		return false
+	default:
+		return true
+	}
+}
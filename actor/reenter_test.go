@@ -0,0 +1,183 @@
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type askMessage struct{}
+
+type answerMessage struct{}
+
+type tickMessage struct{ n int }
+
+// spawnSlowResponder answers every request once release is closed.
+func spawnSlowResponder(t *testing.T) (*PID, chan struct{}) {
+	release := make(chan struct{})
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Envelope().Message.(*askMessage); ok {
+			sender := ctx.Sender()
+			go func() {
+				<-release
+				rootContext.Send(sender, WrapEnvelope(&answerMessage{}))
+			}()
+		}
+	}))
+	t.Cleanup(func() { rootContext.Stop(pid) })
+
+	return pid, release
+}
+
+func TestReenterAfter_DoesNotBlockActor(t *testing.T) {
+	responder, release := spawnSlowResponder(t)
+	events := make(chan interface{}, 10)
+
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Envelope().Message.(type) {
+		case *askMessage:
+			future := ctx.RequestFuture(responder, WrapEnvelope(&askMessage{}), time.Second)
+			ctx.ReenterAfter(future, func(res *MessageEnvelope, err error) {
+				assert.NoError(t, err)
+				events <- res.Message
+				ctx.Respond(WrapEnvelope(&answerMessage{}))
+			})
+		case *tickMessage:
+			events <- msg
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	future := rootContext.RequestFuture(pid, WrapEnvelope(&askMessage{}), 2*time.Second)
+	rootContext.Send(pid, WrapEnvelope(&tickMessage{n: 1}))
+	assert.Equal(t, &tickMessage{n: 1}, waitFor(t, events))
+
+	close(release)
+	assert.IsType(t, &answerMessage{}, waitFor(t, events))
+
+	res, err := future.Result()
+	assert.NoError(t, err)
+	assert.IsType(t, &answerMessage{}, res.Message)
+}
+
+func TestReenterAfter_Timeout(t *testing.T) {
+	responder, release := spawnSlowResponder(t)
+	defer close(release)
+	errs := make(chan error, 1)
+
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Envelope().Message.(*askMessage); ok {
+			future := ctx.RequestFuture(responder, WrapEnvelope(&askMessage{}), 10*time.Millisecond)
+			ctx.ReenterAfter(future, func(res *MessageEnvelope, err error) {
+				errs <- err
+			})
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	rootContext.Send(pid, WrapEnvelope(&askMessage{}))
+	assert.ErrorIs(t, waitFor(t, errs), ErrTimeout)
+}
+
+func TestReenterAfter_StashKeepsMessagesInOrder(t *testing.T) {
+	responder, release := spawnSlowResponder(t)
+	events := make(chan interface{}, 10)
+
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Envelope().Message.(type) {
+		case *askMessage:
+			future := ctx.RequestFuture(responder, WrapEnvelope(&askMessage{}), time.Second)
+			ctx.ReenterAfter(future, func(res *MessageEnvelope, err error) {
+				events <- res.Message
+			}, WithReenterStash())
+		case *tickMessage:
+			events <- msg
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	rootContext.Send(pid, WrapEnvelope(&askMessage{}))
+	for i := 1; i <= 3; i++ {
+		rootContext.Send(pid, WrapEnvelope(&tickMessage{n: i}))
+	}
+
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected message while stashing: %#v", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	assert.IsType(t, &answerMessage{}, waitFor(t, events))
+	for i := 1; i <= 3; i++ {
+		assert.Equal(t, &tickMessage{n: i}, waitFor(t, events))
+	}
+}
+
+func TestReenterAfter_StashSurvivesPanickingContinuation(t *testing.T) {
+	responder, release := spawnSlowResponder(t)
+	events := make(chan interface{}, 10)
+
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Envelope().Message.(type) {
+		case *askMessage:
+			future := ctx.RequestFuture(responder, WrapEnvelope(&askMessage{}), time.Second)
+			ctx.ReenterAfter(future, func(res *MessageEnvelope, err error) {
+				panic("continuation failed")
+			}, WithReenterStash())
+		case *Restarting:
+			events <- msg
+		case *tickMessage:
+			events <- msg
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	rootContext.Send(pid, WrapEnvelope(&askMessage{}))
+	rootContext.Send(pid, WrapEnvelope(&tickMessage{n: 1}))
+
+	close(release)
+	assert.IsType(t, &Restarting{}, waitFor(t, events))
+	assert.Equal(t, &tickMessage{n: 1}, waitFor(t, events))
+
+	rootContext.Send(pid, WrapEnvelope(&tickMessage{n: 2}))
+	assert.Equal(t, &tickMessage{n: 2}, waitFor(t, events))
+}
+
+func TestReenterAfter_ContinuationSkippedAfterRestart(t *testing.T) {
+	responder, release := spawnSlowResponder(t)
+	events := make(chan interface{}, 10)
+
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Envelope().Message.(type) {
+		case *askMessage:
+			future := ctx.RequestFuture(responder, WrapEnvelope(&askMessage{}), time.Second)
+			ctx.ReenterAfter(future, func(res *MessageEnvelope, err error) {
+				events <- res.Message
+			})
+		case *blockMessage:
+			panic("restart")
+		case *Restarting:
+			events <- msg
+		case *tickMessage:
+			events <- msg
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	rootContext.Send(pid, WrapEnvelope(&askMessage{}))
+	rootContext.Send(pid, WrapEnvelope(&blockMessage{}))
+	assert.IsType(t, &Restarting{}, waitFor(t, events))
+
+	// the continuation of the previous incarnation is dropped, the next message comes first
+	close(release)
+	time.Sleep(20 * time.Millisecond)
+	rootContext.Send(pid, WrapEnvelope(&tickMessage{n: 1}))
+	assert.Equal(t, &tickMessage{n: 1}, waitFor(t, events))
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected continuation after restart: %#v", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
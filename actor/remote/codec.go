@@ -0,0 +1,184 @@
+package remote
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/colin1989/battery/actor"
+)
+
+// ErrFrameSizeExceed is returned when a peer announces a frame larger than Config.MaxFrameSize.
+var ErrFrameSizeExceed = errors.New("remote: frame size exceed")
+
+var errMalformedFrame = errors.New("remote: malformed frame")
+
+// frameHeadLength is the size of the big endian length prefix of every frame
+const frameHeadLength = 4
+
+type frameKind byte
+
+const (
+	userFrame frameKind = iota + 1
+	systemFrame
+)
+
+// frame is the wire representation of a message sent to a remote PID.
+//
+// Layout (after the 4 byte length prefix):
+//
+//	kind | target | sender? | header | serializer id | type name | data
+type frame struct {
+	kind         frameKind
+	target       *actor.PID
+	sender       *actor.PID
+	header       map[string]string
+	serializerID byte
+	typeName     string
+	data         []byte
+}
+
+func (f *frame) encode() []byte {
+	buf := make([]byte, frameHeadLength, frameHeadLength+64+len(f.data))
+	buf = append(buf, byte(f.kind))
+	buf = appendPID(buf, f.target)
+	if f.sender == nil {
+		buf = append(buf, 0)
+	} else {
+		buf = append(buf, 1)
+		buf = appendPID(buf, f.sender)
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(f.header)))
+	for k, v := range f.header {
+		buf = appendString(buf, k)
+		buf = appendString(buf, v)
+	}
+	buf = append(buf, f.serializerID)
+	buf = appendString(buf, f.typeName)
+	buf = appendBytes(buf, f.data)
+
+	binary.BigEndian.PutUint32(buf, uint32(len(buf)-frameHeadLength))
+	return buf
+}
+
+func appendPID(buf []byte, pid *actor.PID) []byte {
+	buf = appendString(buf, pid.Address)
+	buf = appendString(buf, pid.ID)
+	return binary.AppendUvarint(buf, uint64(pid.RequestId))
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+func appendBytes(buf []byte, b []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(b)))
+	return append(buf, b...)
+}
+
+// readFrame reads one length prefixed frame from r.
+func readFrame(r io.Reader, maxFrameSize int) (*frame, error) {
+	var head [frameHeadLength]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return nil, err
+	}
+
+	size := int(binary.BigEndian.Uint32(head[:]))
+	if size > maxFrameSize {
+		return nil, ErrFrameSizeExceed
+	}
+
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+
+	return decodeFrame(body)
+}
+
+func decodeFrame(body []byte) (*frame, error) {
+	d := &frameDecoder{buf: body}
+	f := &frame{}
+	f.kind = frameKind(d.byte())
+	f.target = d.pid()
+	if d.byte() == 1 {
+		f.sender = d.pid()
+	}
+	if n := d.uvarint(); n > 0 {
+		f.header = make(map[string]string, n)
+		for i := uint64(0); i < n && d.err == nil; i++ {
+			k := d.string()
+			f.header[k] = d.string()
+		}
+	}
+	f.serializerID = d.byte()
+	f.typeName = d.string()
+	f.data = d.bytes()
+
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	return f, nil
+}
+
+type frameDecoder struct {
+	buf []byte
+	err error
+}
+
+func (d *frameDecoder) byte() byte {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.buf) < 1 {
+		d.err = errMalformedFrame
+		return 0
+	}
+
+	b := d.buf[0]
+	d.buf = d.buf[1:]
+	return b
+}
+
+func (d *frameDecoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.err = errMalformedFrame
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *frameDecoder) bytes() []byte {
+	n := d.uvarint()
+	if d.err != nil {
+		return nil
+	}
+	if uint64(len(d.buf)) < n {
+		d.err = errMalformedFrame
+		return nil
+	}
+
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b
+}
+
+func (d *frameDecoder) string() string {
+	return string(d.bytes())
+}
+
+func (d *frameDecoder) pid() *actor.PID {
+	address := d.string()
+	id := d.string()
+	pid := actor.NewPID(address, id)
+	pid.RequestId = uint32(d.uvarint())
+	return pid
+}
@@ -0,0 +1,44 @@
+package remote
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+type Config struct {
+	Host           string
+	Port           int
+	AdvertisedHost string
+	DialTimeout    time.Duration
+	WriteQueueSize int
+	MaxFrameSize   int
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		Host:           "localhost",
+		Port:           0,
+		DialTimeout:    5 * time.Second,
+		WriteQueueSize: 1024,
+		MaxFrameSize:   4 * 1024 * 1024,
+	}
+}
+
+// Configure creates a remote config listening on host:port.
+// A port of 0 lets the operating system pick a free port.
+func Configure(host string, port int, options ...ConfigOption) *Config {
+	config := defaultConfig()
+	config.Host = host
+	config.Port = port
+	for _, option := range options {
+		option(config)
+	}
+
+	return config
+}
+
+// Address returns the address the endpoint listens on.
+func (c *Config) Address() string {
+	return net.JoinHostPort(c.Host, fmt.Sprintf("%d", c.Port))
+}
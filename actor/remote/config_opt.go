@@ -0,0 +1,34 @@
+package remote
+
+import "time"
+
+type ConfigOption func(config *Config)
+
+// WithAdvertisedHost sets the host:port other nodes use to reach this endpoint,
+// e.g. when listening on 0.0.0.0 behind a NAT.
+func WithAdvertisedHost(address string) ConfigOption {
+	return func(config *Config) {
+		config.AdvertisedHost = address
+	}
+}
+
+// WithDialTimeout sets the timeout used when connecting to another endpoint
+func WithDialTimeout(timeout time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.DialTimeout = timeout
+	}
+}
+
+// WithWriteQueueSize sets the number of frames buffered for each outbound connection
+func WithWriteQueueSize(size int) ConfigOption {
+	return func(config *Config) {
+		config.WriteQueueSize = size
+	}
+}
+
+// WithMaxFrameSize sets the largest frame accepted from another endpoint
+func WithMaxFrameSize(size int) ConfigOption {
+	return func(config *Config) {
+		config.MaxFrameSize = size
+	}
+}
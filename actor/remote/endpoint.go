@@ -0,0 +1,114 @@
+package remote
+
+import (
+	"log/slog"
+	"net"
+	"sync"
+)
+
+// endpointWriter owns the outbound connection to one remote address.
+// Frames are written by a single goroutine so the ordering between two actor systems is preserved.
+type endpointWriter struct {
+	remote  *Remote
+	address string
+	queue   chan *frame
+	stop    chan struct{}
+	once    sync.Once
+	conn    net.Conn
+}
+
+func newEndpointWriter(remote *Remote, address string) *endpointWriter {
+	ew := &endpointWriter{
+		remote:  remote,
+		address: address,
+		queue:   make(chan *frame, remote.config.WriteQueueSize),
+		stop:    make(chan struct{}),
+	}
+	// Shutdown waits for the writer to drain its queue
+	remote.wg.Add(1)
+	go ew.run()
+
+	return ew
+}
+
+// send queues f for writing, it never blocks the calling actor.
+func (ew *endpointWriter) send(f *frame) {
+	// a stopped writer no longer drains its queue
+	select {
+	case <-ew.stop:
+		ew.remote.undeliverable(f)
+		return
+	default:
+	}
+
+	select {
+	case <-ew.stop:
+		ew.remote.undeliverable(f)
+	case ew.queue <- f:
+	default:
+		ew.remote.actorSystem.Logger().Warn("remote endpoint queue is full",
+			slog.String("address", ew.address))
+		ew.remote.undeliverable(f)
+	}
+}
+
+func (ew *endpointWriter) run() {
+	defer ew.remote.wg.Done()
+
+	for {
+		select {
+		case <-ew.stop:
+			ew.closeConn()
+			ew.drain()
+			return
+		case f := <-ew.queue:
+			ew.write(f)
+		}
+	}
+}
+
+func (ew *endpointWriter) write(f *frame) {
+	if ew.conn == nil {
+		conn, err := net.DialTimeout("tcp", ew.address, ew.remote.config.DialTimeout)
+		if err != nil {
+			ew.remote.actorSystem.Logger().Warn("remote endpoint dial failed",
+				slog.String("address", ew.address), slog.Any("err", err))
+			ew.remote.undeliverable(f)
+			return
+		}
+		ew.conn = conn
+	}
+
+	if _, err := ew.conn.Write(f.encode()); err != nil {
+		ew.remote.actorSystem.Logger().Warn("remote endpoint write failed",
+			slog.String("address", ew.address), slog.Any("err", err))
+		ew.closeConn()
+		ew.remote.undeliverable(f)
+	}
+}
+
+func (ew *endpointWriter) drain() {
+	for {
+		select {
+		case f := <-ew.queue:
+			ew.remote.undeliverable(f)
+		default:
+			return
+		}
+	}
+}
+
+func (ew *endpointWriter) closeConn() {
+	if ew.conn == nil {
+		return
+	}
+
+	_ = ew.conn.Close()
+	ew.conn = nil
+}
+
+func (ew *endpointWriter) close() {
+	ew.once.Do(func() {
+		close(ew.stop)
+	})
+}
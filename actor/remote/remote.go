@@ -0,0 +1,301 @@
+package remote
+
+import (
+	"errors"
+	"log/slog"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/colin1989/battery/actor"
+)
+
+// Remote is the TCP endpoint of an actor system.
+// Once started, PIDs whose address is another endpoint are transparently delivered over the network.
+type Remote struct {
+	actorSystem *actor.ActorSystem
+	config      *Config
+	listener    net.Listener
+	address     string
+	stopped     int32
+
+	mu        sync.Mutex
+	endpoints map[string]*endpointWriter
+	conns     map[net.Conn]struct{}
+	wg        sync.WaitGroup
+}
+
+func NewRemote(actorSystem *actor.ActorSystem, config *Config) *Remote {
+	return &Remote{
+		actorSystem: actorSystem,
+		config:      config,
+		endpoints:   make(map[string]*endpointWriter),
+		conns:       make(map[net.Conn]struct{}),
+	}
+}
+
+// Start listens on the configured address and assigns it to the actor system.
+func (r *Remote) Start() error {
+	listener, err := net.Listen("tcp", r.config.Address())
+	if err != nil {
+		return err
+	}
+	r.listener = listener
+
+	r.address = r.config.AdvertisedHost
+	if r.address == "" {
+		r.address = listener.Addr().String()
+	}
+
+	r.actorSystem.ProcessRegistry.Address = r.address
+	r.actorSystem.ProcessRegistry.RegisterAddressResolver(func(pid *actor.PID) (actor.Process, bool) {
+		if atomic.LoadInt32(&r.stopped) == 1 {
+			return nil, false
+		}
+		return newProcess(pid, r), true
+	})
+
+	r.wg.Add(1)
+	go r.accept()
+
+	r.actorSystem.Logger().Info("remote started", slog.String("address", r.address))
+	return nil
+}
+
+// Shutdown closes the listener and every inbound and outbound connection.
+func (r *Remote) Shutdown() {
+	if !atomic.CompareAndSwapInt32(&r.stopped, 0, 1) {
+		return
+	}
+
+	_ = r.listener.Close()
+
+	r.mu.Lock()
+	for _, ew := range r.endpoints {
+		ew.close()
+	}
+	for conn := range r.conns {
+		_ = conn.Close()
+	}
+	r.mu.Unlock()
+
+	r.wg.Wait()
+	r.actorSystem.Logger().Info("remote stopped", slog.String("address", r.address))
+}
+
+// Address returns the host:port other actor systems use to reach this one.
+func (r *Remote) Address() string {
+	return r.address
+}
+
+func (r *Remote) ActorSystem() *actor.ActorSystem {
+	return r.actorSystem
+}
+
+func (r *Remote) accept() {
+	defer r.wg.Done()
+
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				r.actorSystem.Logger().Error("remote accept failed", slog.Any("err", err))
+			}
+			return
+		}
+
+		r.mu.Lock()
+		r.conns[conn] = struct{}{}
+		r.mu.Unlock()
+
+		r.wg.Add(1)
+		go r.read(conn)
+	}
+}
+
+func (r *Remote) read(conn net.Conn) {
+	defer func() {
+		r.mu.Lock()
+		delete(r.conns, conn)
+		r.mu.Unlock()
+		_ = conn.Close()
+		r.wg.Done()
+	}()
+
+	for {
+		f, err := readFrame(conn, r.config.MaxFrameSize)
+		if err != nil {
+			if atomic.LoadInt32(&r.stopped) == 0 && !errors.Is(err, net.ErrClosed) {
+				r.actorSystem.Logger().Debug("remote connection closed",
+					slog.String("peer", conn.RemoteAddr().String()), slog.Any("err", err))
+			}
+			return
+		}
+
+		r.deliver(f)
+	}
+}
+
+// deliver hands an inbound frame to the local target process.
+func (r *Remote) deliver(f *frame) {
+	msg, err := r.decodeMessage(f)
+	if err != nil {
+		r.actorSystem.Logger().Error("remote decode message failed",
+			slog.String("type", f.typeName), slog.Any("err", err))
+		return
+	}
+
+	target := r.actorSystem.NewLocalPID(f.target.ID)
+	target.RequestId = f.target.RequestId
+	ref, _ := r.actorSystem.ProcessRegistry.Get(target)
+
+	switch f.kind {
+	case userFrame:
+		ref.SendUserMessage(target, &actor.MessageEnvelope{
+			Header:  f.header,
+			Message: msg,
+			Sender:  f.sender,
+		})
+	case systemFrame:
+		sys, ok := msg.(actor.SystemMessage)
+		if !ok {
+			r.actorSystem.Logger().Error("remote frame is not a system message", slog.String("type", f.typeName))
+			return
+		}
+		ref.SendSystemMessage(target, sys)
+	}
+}
+
+func (r *Remote) decodeMessage(f *frame) (interface{}, error) {
+	if f.typeName == "" {
+		return nil, nil
+	}
+
+	return Deserialize(f.data, f.typeName, f.serializerID)
+}
+
+func (r *Remote) sendUserMessage(pid *actor.PID, envelope *actor.MessageEnvelope) {
+	header, msg, sender := actor.UnwrapEnvelope(envelope)
+
+	f := &frame{
+		kind:   userFrame,
+		target: pid,
+		sender: r.globalPID(sender),
+	}
+	if header != nil && header.Length() > 0 {
+		f.header = header.ToMap()
+	}
+
+	if err := r.encodeMessage(f, msg); err != nil {
+		r.actorSystem.Logger().Error("remote serialize message failed", slog.Any("err", err))
+		r.undeliverable(f)
+		return
+	}
+
+	r.send(pid.Address, f)
+}
+
+func (r *Remote) sendSystemMessage(pid *actor.PID, message actor.SystemMessage) {
+	f := &frame{
+		kind:   systemFrame,
+		target: pid,
+	}
+
+	if err := r.encodeMessage(f, r.globalSystemMessage(message)); err != nil {
+		r.actorSystem.Logger().Error("remote serialize system message failed", slog.Any("err", err))
+		r.undeliverable(f)
+		return
+	}
+
+	r.send(pid.Address, f)
+}
+
+func (r *Remote) encodeMessage(f *frame, msg interface{}) error {
+	if msg == nil {
+		return nil
+	}
+
+	data, typeName, serializerID, err := Serialize(msg)
+	if err != nil {
+		return err
+	}
+
+	f.data = data
+	f.typeName = typeName
+	f.serializerID = serializerID
+	return nil
+}
+
+// send hands f to the endpoint writer of address, once the remote is stopped f is undeliverable.
+func (r *Remote) send(address string, f *frame) {
+	ew := r.endpoint(address)
+	if ew == nil {
+		r.undeliverable(f)
+		return
+	}
+	ew.send(f)
+}
+
+// endpoint returns the writer of address, started on first use, or nil once the remote is stopped.
+func (r *Remote) endpoint(address string) *endpointWriter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Shutdown closes the writers under mu after setting stopped, a later writer would never be closed
+	if atomic.LoadInt32(&r.stopped) == 1 {
+		return nil
+	}
+
+	ew, ok := r.endpoints[address]
+	if !ok {
+		ew = newEndpointWriter(r, address)
+		r.endpoints[address] = ew
+	}
+
+	return ew
+}
+
+// undeliverable reports a frame that could not reach its endpoint.
+// Watchers get a Terminated back, everything else goes to the dead letter process.
+func (r *Remote) undeliverable(f *frame) {
+	msg, _ := r.decodeMessage(f)
+
+	if watch, ok := msg.(*actor.Watch); ok {
+		ref, _ := r.actorSystem.ProcessRegistry.Get(watch.Watcher)
+		ref.SendSystemMessage(watch.Watcher, &actor.Terminated{
+			Who: f.target,
+			Why: actor.TerminatedReason_AddressTerminated,
+		})
+		return
+	}
+
+	r.actorSystem.EventStream.Publish(&actor.DeadLetterEvent{
+		PID:     f.target,
+		Message: msg,
+		Sender:  f.sender,
+	})
+}
+
+// globalPID gives a local PID the address of this endpoint so the far side can reply to it.
+func (r *Remote) globalPID(pid *actor.PID) *actor.PID {
+	if pid == nil || pid.Address == r.address || !r.actorSystem.ProcessRegistry.IsLocal(pid) {
+		return pid
+	}
+
+	global := actor.NewPID(r.address, pid.ID)
+	global.RequestId = pid.RequestId
+	return global
+}
+
+func (r *Remote) globalSystemMessage(message actor.SystemMessage) actor.SystemMessage {
+	switch msg := message.(type) {
+	case *actor.Watch:
+		return &actor.Watch{Watcher: r.globalPID(msg.Watcher)}
+	case *actor.Unwatch:
+		return &actor.Unwatch{Watcher: r.globalPID(msg.Watcher)}
+	case *actor.Terminated:
+		return &actor.Terminated{Who: r.globalPID(msg.Who), Why: msg.Why}
+	default:
+		return message
+	}
+}
@@ -0,0 +1,30 @@
+package remote
+
+import "github.com/colin1989/battery/actor"
+
+// process is the local stand-in for an actor living in another actor system.
+type process struct {
+	pid    *actor.PID
+	remote *Remote
+}
+
+var _ actor.Process = &process{}
+
+func newProcess(pid *actor.PID, remote *Remote) actor.Process {
+	return &process{
+		pid:    pid,
+		remote: remote,
+	}
+}
+
+func (ref *process) SendUserMessage(pid *actor.PID, envelope *actor.MessageEnvelope) {
+	ref.remote.sendUserMessage(pid, envelope)
+}
+
+func (ref *process) SendSystemMessage(pid *actor.PID, message actor.SystemMessage) {
+	ref.remote.sendSystemMessage(pid, message)
+}
+
+func (ref *process) Stop(pid *actor.PID) {
+	ref.SendSystemMessage(pid, &actor.Stop{})
+}
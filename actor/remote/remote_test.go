@@ -0,0 +1,148 @@
+package remote
+
+import (
+	"testing"
+	"time"
+
+	"github.com/colin1989/battery/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ping struct {
+	Text string
+}
+
+type pong struct {
+	Text string
+}
+
+func init() {
+	RegisterType(&ping{}, &pong{})
+}
+
+func startRemote(t *testing.T) *Remote {
+	t.Helper()
+	r := NewRemote(actor.NewActorSystem(), Configure("127.0.0.1", 0))
+	require.NoError(t, r.Start())
+	t.Cleanup(r.Shutdown)
+
+	return r
+}
+
+func TestRemote_Request(t *testing.T) {
+	r1 := startRemote(t)
+	r2 := startRemote(t)
+
+	props := actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Envelope().Message.(*ping); ok {
+			assert.Equal(t, "trace", ctx.Envelope().GetHeader("trace-id"))
+			ctx.Respond(actor.WrapEnvelope(&pong{Text: msg.Text}))
+		}
+	})
+	_, err := r2.ActorSystem().Root.SpawnNamed(props, "echo")
+	require.NoError(t, err)
+
+	target := actor.NewPID(r2.Address(), "echo")
+	envelope := actor.WrapEnvelope(&ping{Text: "hello"})
+	envelope.SetHeader("trace-id", "trace")
+
+	resp, err := r1.ActorSystem().Root.Request(target, envelope)
+	require.NoError(t, err)
+	assert.Equal(t, &pong{Text: "hello"}, resp.Message)
+}
+
+func TestRemote_RequestUnknownActorReturnsDeadLetter(t *testing.T) {
+	r1 := startRemote(t)
+	r2 := startRemote(t)
+
+	_, err := r1.ActorSystem().Root.Request(actor.NewPID(r2.Address(), "missing"), actor.WrapEnvelope(&ping{}))
+	assert.Equal(t, actor.ErrDeadLetter, err)
+}
+
+func TestRemote_WatchRemoteActor(t *testing.T) {
+	r1 := startRemote(t)
+	r2 := startRemote(t)
+
+	pid, err := r2.ActorSystem().Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {}), "watched")
+	require.NoError(t, err)
+	remotePID := actor.NewPID(r2.Address(), pid.ID)
+
+	terminated := make(chan *actor.Terminated, 1)
+	started := make(chan struct{})
+	r1.ActorSystem().Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Envelope().Message.(type) {
+		case *actor.Started:
+			ctx.Watch(remotePID)
+			close(started)
+		case *actor.Terminated:
+			terminated <- msg
+		}
+	}))
+
+	<-started
+	// give the watch a moment to cross the wire before stopping the target
+	time.Sleep(100 * time.Millisecond)
+	r2.ActorSystem().Root.Stop(pid)
+
+	select {
+	case msg := <-terminated:
+		assert.Equal(t, remotePID.ID, msg.Who.ID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("watcher was not notified")
+	}
+}
+
+func TestRemote_UnreachableAddress(t *testing.T) {
+	r1 := startRemote(t)
+
+	_, err := r1.ActorSystem().Root.Request(actor.NewPID("127.0.0.1:1", "nobody"), actor.WrapEnvelope(&ping{}))
+	assert.Equal(t, actor.ErrDeadLetter, err)
+}
+
+func TestRemote_SendAfterShutdownIsDeadLetter(t *testing.T) {
+	r1 := startRemote(t)
+	r2 := startRemote(t)
+
+	deadLetters := make(chan *actor.DeadLetterEvent, 1)
+	r1.ActorSystem().EventStream.Subscribe(func(evt actor.EventMessage) {
+		if deadLetter, ok := evt.(*actor.DeadLetterEvent); ok {
+			deadLetters <- deadLetter
+		}
+	})
+	r1.Shutdown()
+
+	target := actor.NewPID(r2.Address(), "echo")
+	newProcess(target, r1).SendUserMessage(target, actor.WrapEnvelope(&ping{Text: "late"}))
+
+	select {
+	case deadLetter := <-deadLetters:
+		assert.Equal(t, &ping{Text: "late"}, deadLetter.Message)
+	case <-time.After(2 * time.Second):
+		t.Fatal("frame sent after shutdown was not a dead letter")
+	}
+	assert.Empty(t, r1.endpoints)
+}
+
+func TestFrame_EncodeDecode(t *testing.T) {
+	sender := actor.NewPID("127.0.0.1:8080", "sender")
+	sender.RequestId = 7
+	f := &frame{
+		kind:         userFrame,
+		target:       actor.NewPID("127.0.0.1:8081", "target"),
+		sender:       sender,
+		header:       map[string]string{"k": "v"},
+		serializerID: 1,
+		typeName:     "type",
+		data:         []byte("data"),
+	}
+
+	decoded, err := decodeFrame(f.encode()[frameHeadLength:])
+	require.NoError(t, err)
+	assert.Equal(t, f.kind, decoded.kind)
+	assert.Equal(t, f.target.ID, decoded.target.ID)
+	assert.Equal(t, uint32(7), decoded.sender.RequestId)
+	assert.Equal(t, f.header, decoded.header)
+	assert.Equal(t, f.typeName, decoded.typeName)
+	assert.Equal(t, f.data, decoded.data)
+}
@@ -0,0 +1,40 @@
+package remote
+
+import (
+	serialize "github.com/colin1989/battery/serializer"
+)
+
+var (
+	// ErrUnknownSerializer is returned when a frame references a serializer that is not registered.
+	ErrUnknownSerializer = serialize.ErrUnknownSerializer
+
+	// ErrUnknownType is returned when no serializer knows how to encode or decode a message type.
+	ErrUnknownType = serialize.ErrUnknownType
+)
+
+// Serializer converts messages to and from their wire representation.
+type Serializer = serialize.MessageSerializer
+
+// RegisterSerializer appends a serializer and returns its id.
+// Every node must register the same serializers in the same order.
+func RegisterSerializer(serializer Serializer) byte {
+	return serialize.RegisterMessageSerializer(serializer)
+}
+
+// RegisterType registers a non-protobuf message type so it can be sent as JSON.
+// Every node must register the type before messages of that type are exchanged.
+// The registry is shared with the other users of serialize.Serialize, e.g. the persistence journal.
+func RegisterType(prototypes ...interface{}) {
+	serialize.RegisterType(prototypes...)
+}
+
+// Serialize encodes msg with the first serializer able to, and returns the type name and serializer id
+// needed to decode it with Deserialize.
+func Serialize(msg interface{}) ([]byte, string, byte, error) {
+	return serialize.Serialize(msg)
+}
+
+// Deserialize decodes data produced by Serialize
+func Deserialize(data []byte, typeName string, serializerID byte) (interface{}, error) {
+	return serialize.Deserialize(data, typeName, serializerID)
+}
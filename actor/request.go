@@ -0,0 +1,94 @@
+package actor
+
+import (
+	"errors"
+	"time"
+)
+
+type requestConfig struct {
+	timeout time.Duration
+	sender  *PID
+	header  map[string]string
+	retries int
+}
+
+// RequestOption configures Request
+type RequestOption func(config *requestConfig)
+
+// WithRequestTimeout overrides Config.DefaultRequestTimeout for this request
+func WithRequestTimeout(timeout time.Duration) RequestOption {
+	return func(config *requestConfig) {
+		config.timeout = timeout
+	}
+}
+
+// WithRequestSender forwards the response, or the error, of the request to sender as well, the way Future.PipeTo does.
+// Request still waits for the response and returns it.
+func WithRequestSender(sender *PID) RequestOption {
+	return func(config *requestConfig) {
+		config.sender = sender
+	}
+}
+
+// WithRequestHeader sets a header of the request, the envelope passed to Request is not modified.
+func WithRequestHeader(key, value string) RequestOption {
+	return func(config *requestConfig) {
+		if config.header == nil {
+			config.header = make(map[string]string)
+		}
+		config.header[key] = value
+	}
+}
+
+// WithRetryOnTimeout sends the request again, at most retries times, when no response came in time.
+// The receiver may then handle the request more than once, only use it for idempotent requests.
+func WithRetryOnTimeout(retries int) RequestOption {
+	return func(config *requestConfig) {
+		config.retries = retries
+	}
+}
+
+func newRequestConfig(actorSystem *ActorSystem, opts ...RequestOption) *requestConfig {
+	config := &requestConfig{
+		timeout: actorSystem.Config.DefaultRequestTimeout,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return config
+}
+
+// envelope returns a copy of envelope with the headers of the request,
+// every attempt sends its own copy since the sender of the previous one is its expired future.
+func (config *requestConfig) envelope(envelope *MessageEnvelope) *MessageEnvelope {
+	msg := &MessageEnvelope{
+		Header:  envelope.Header,
+		Message: envelope.Message,
+		Sender:  envelope.Sender,
+	}
+	if len(config.header) > 0 {
+		msg.Header = envelope.Header.ToMap()
+		for k, v := range config.header {
+			msg.Header.Set(k, v)
+		}
+	}
+
+	return msg
+}
+
+// request implements Request for the contexts, on top of their Send and RequestFuture
+func request(sender SenderContext, pid *PID, envelope *MessageEnvelope, opts ...RequestOption) (*MessageEnvelope, error) {
+	config := newRequestConfig(sender.ActorSystem(), opts...)
+
+	for attempt := 0; ; attempt++ {
+		future := sender.RequestFuture(pid, config.envelope(envelope), config.timeout)
+		res, err := future.Result()
+		if !errors.Is(err, ErrTimeout) || attempt >= config.retries {
+			if config.sender != nil {
+				future.PipeTo(config.sender)
+			}
+			return res, err
+		}
+	}
+}
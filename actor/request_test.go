@@ -0,0 +1,117 @@
+package actor
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequest_DefaultTimeoutFromConfig(t *testing.T) {
+	as := NewActorSystem(WithDefaultRequestTimeout(20 * time.Millisecond))
+	silent := as.Root.Spawn(PropsFromFunc(func(ctx Context) {}))
+	defer as.Root.Stop(silent)
+
+	start := time.Now()
+	_, err := as.Root.Request(silent, WrapEnvelope(&askMessage{}))
+	assert.True(t, errors.Is(err, ErrTimeout))
+	assert.Less(t, time.Since(start), time.Second)
+
+	_, err = as.Root.Request(silent, WrapEnvelope(&askMessage{}), WithRequestTimeout(time.Millisecond))
+	assert.True(t, errors.Is(err, ErrTimeout))
+}
+
+func TestRequest_PartialConfigGetsDefaults(t *testing.T) {
+	as := NewActorSystemWithConfig(&Config{DefaultStopTimeout: time.Second})
+	assert.NotNil(t, as.Config.Clock)
+	assert.Equal(t, defaultConfig().DefaultRequestTimeout, as.Config.DefaultRequestTimeout)
+	assert.Equal(t, time.Second, as.Config.DefaultStopTimeout)
+
+	echo := as.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Envelope().Message.(*askMessage); ok {
+			ctx.Respond(WrapEnvelope(&answerMessage{}))
+		}
+	}))
+	res, err := as.Root.Request(echo, WrapEnvelope(&askMessage{}))
+	require.NoError(t, err)
+	assert.IsType(t, &answerMessage{}, res.Message)
+	require.NoError(t, as.Root.StopFuture(echo).Wait())
+}
+
+func TestRequest_RetryOnTimeout(t *testing.T) {
+	var attempts int32
+	// answers the third attempt only
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Envelope().Message.(*askMessage); ok {
+			if atomic.AddInt32(&attempts, 1) == 3 {
+				ctx.Respond(WrapEnvelope(&answerMessage{}))
+			}
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	_, err := rootContext.Request(pid, WrapEnvelope(&askMessage{}),
+		WithRequestTimeout(20*time.Millisecond), WithRetryOnTimeout(1))
+	assert.True(t, errors.Is(err, ErrTimeout))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+
+	res, err := rootContext.Request(pid, WrapEnvelope(&askMessage{}),
+		WithRequestTimeout(20*time.Millisecond), WithRetryOnTimeout(3))
+	require.NoError(t, err)
+	assert.IsType(t, &answerMessage{}, res.Message)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestRequest_HeaderAndSender(t *testing.T) {
+	headers := make(chan string, 1)
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Envelope().Message.(*askMessage); ok {
+			headers <- ctx.MessageHeader().Get("player")
+			ctx.Respond(WrapEnvelope(&answerMessage{}))
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	envelope := WrapEnvelope(&askMessage{})
+	res, err := rootContext.Request(pid, envelope, WithRequestHeader("player", "42"))
+	require.NoError(t, err)
+	assert.IsType(t, &answerMessage{}, res.Message)
+	assert.Equal(t, "42", waitFor(t, headers))
+	assert.Empty(t, envelope.GetHeader("player"), "the envelope of the caller is not modified")
+
+	answers := make(chan interface{}, 1)
+	receiver := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Envelope().Message.(*answerMessage); ok {
+			answers <- msg
+		}
+	}))
+	defer rootContext.Stop(receiver)
+
+	res, err = rootContext.Request(pid, WrapEnvelope(&askMessage{}), WithRequestSender(receiver))
+	require.NoError(t, err)
+	assert.IsType(t, &answerMessage{}, res.Message)
+	waitFor(t, headers)
+	assert.IsType(t, &answerMessage{}, waitFor(t, answers))
+}
+
+func TestRequest_SenderReceivesTheError(t *testing.T) {
+	silent := rootContext.Spawn(PropsFromFunc(func(ctx Context) {}))
+	defer rootContext.Stop(silent)
+
+	errs := make(chan error, 1)
+	receiver := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if err, ok := ctx.Envelope().Message.(error); ok {
+			errs <- err
+		}
+	}))
+	defer rootContext.Stop(receiver)
+
+	res, err := rootContext.Request(silent, WrapEnvelope(&askMessage{}),
+		WithRequestTimeout(10*time.Millisecond), WithRequestSender(receiver))
+	assert.Nil(t, res)
+	assert.True(t, errors.Is(err, ErrTimeout))
+	assert.Equal(t, ErrTimeout, waitFor(t, errs))
+}
@@ -106,14 +106,17 @@ func (rc *RootContext) Send(pid *PID, envelope *MessageEnvelope) {
 //	@param message message's type cannot be MessageEnvelope
 //	@return *MessageEnvelope
 //	@return error
-func (rc *RootContext) Request(pid *PID, envelope *MessageEnvelope) (*MessageEnvelope, error) {
-	// TODO: timeout 应该作为配置
-	timeout := time.Second * 5
+func (rc *RootContext) Request(pid *PID, envelope *MessageEnvelope, opts ...RequestOption) (*MessageEnvelope, error) {
+	return request(rc, pid, envelope, opts...)
+}
+
+// RequestFuture sends a message to a given PID and returns a Future without blocking.
+func (rc *RootContext) RequestFuture(pid *PID, envelope *MessageEnvelope, timeout time.Duration) *Future {
 	future := NewFuture(rc.actorSystem, timeout)
 	envelope.Sender = future.pid
 
 	pid.sendUserMessage(rc.actorSystem, envelope)
-	return future.Result()
+	return future
 }
 
 //
@@ -134,7 +137,7 @@ func (rc *RootContext) Stop(pid *PID) {
 
 // StopFuture will stop actor immediately regardless of existing user messages in mailbox, and return its future.
 func (rc *RootContext) StopFuture(pid *PID) *Future {
-	future := NewFuture(rc.actorSystem, 10*time.Second)
+	future := NewFuture(rc.actorSystem, rc.actorSystem.Config.DefaultStopTimeout)
 
 	pid.sendSystemMessage(rc.actorSystem, &Watch{Watcher: future.pid})
 	rc.Stop(pid)
@@ -148,7 +151,7 @@ func (rc *RootContext) Poison(pid *PID) {
 
 // PoisonFuture will tell actor to stop after processing current user messages in mailbox, and return its future.
 func (rc *RootContext) PoisonFuture(pid *PID) *Future {
-	future := NewFuture(rc.actorSystem, 10*time.Second)
+	future := NewFuture(rc.actorSystem, rc.actorSystem.Config.DefaultStopTimeout)
 
 	pid.sendSystemMessage(rc.actorSystem, &Watch{Watcher: future.pid})
 	rc.Poison(pid)
@@ -179,9 +182,17 @@ func (rc *RootContext) SpawnPrefix(props *Props, prefix string) *PID {
 }
 
 func (rc *RootContext) SpawnNamed(props *Props, name string) (*PID, error) {
+	var pid *PID
+	var err error
 	if rc.spawnMiddleware != nil {
-		return rc.spawnMiddleware(rc.actorSystem, name, props, rc)
+		pid, err = rc.spawnMiddleware(rc.actorSystem, name, props, rc)
+	} else {
+		pid, err = props.spawn(rc.actorSystem, name, rc)
+	}
+
+	if err == nil && rc.actorSystem.ProcessRegistry.IsLocal(pid) {
+		rc.actorSystem.ProcessRegistry.addTopLevel(pid)
 	}
 
-	return props.spawn(rc.actorSystem, name, rc)
+	return pid, err
 }
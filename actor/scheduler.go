@@ -0,0 +1,198 @@
+package actor
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// NotInfluenceReceiveTimeoutHeader marks a single send as not resetting the ReceiveTimeout of the receiver,
+// the same way a message implementing NotInfluenceReceiveTimeout does.
+const NotInfluenceReceiveTimeoutHeader = "not-influence-receive-timeout"
+
+// CancelFunc stops a scheduled send. Calling it more than once, or after a one-shot send fired, is a no-op.
+type CancelFunc func()
+
+type timerConfig struct {
+	notInfluenceReceiveTimeout bool
+}
+
+// TimerOption configures a scheduled send
+type TimerOption func(config *timerConfig)
+
+// WithNotInfluenceReceiveTimeout keeps the scheduled messages from resetting the ReceiveTimeout of the receiver.
+func WithNotInfluenceReceiveTimeout() TimerOption {
+	return func(config *timerConfig) {
+		config.notInfluenceReceiveTimeout = true
+	}
+}
+
+// Scheduler sends messages to PIDs after a delay or at a fixed interval.
+// The scheduler of an actor is returned by Context.Scheduler and its timers are cancelled when the actor stops,
+// ActorSystem.Scheduler is not bound to any actor and lives until the system shuts down.
+type Scheduler struct {
+	actorSystem *ActorSystem
+	mu          sync.Mutex
+	nextID      uint64
+	timers      map[uint64]*scheduledTimer
+	stopped     bool
+}
+
+type scheduledTimer struct {
+	mu        sync.Mutex
+	timer     Timer
+	cancelled bool
+}
+
+func (t *scheduledTimer) cancel() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cancelled = true
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
+
+func (t *scheduledTimer) isCancelled() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.cancelled
+}
+
+// NewScheduler creates a scheduler sending its messages through actorSystem
+func NewScheduler(actorSystem *ActorSystem) *Scheduler {
+	return &Scheduler{
+		actorSystem: actorSystem,
+		timers:      make(map[uint64]*scheduledTimer),
+	}
+}
+
+// SendOnce sends envelope to pid once delay elapsed.
+func (s *Scheduler) SendOnce(delay time.Duration, pid *PID, envelope *MessageEnvelope, opts ...TimerOption) CancelFunc {
+	config := newTimerConfig(opts...)
+	id, t, ok := s.add()
+	if !ok {
+		return func() {}
+	}
+
+	t.mu.Lock()
+	t.timer = s.actorSystem.Config.Clock.AfterFunc(delay, func() {
+		s.remove(id)
+		if t.isCancelled() {
+			return
+		}
+
+		s.send(pid, envelope, config)
+	})
+	t.mu.Unlock()
+
+	return s.cancelFunc(id, t)
+}
+
+// SendRepeatedly sends envelope to pid after initial, and then every interval until cancelled.
+// It panics when interval is not positive, like time.NewTicker.
+func (s *Scheduler) SendRepeatedly(initial, interval time.Duration, pid *PID, envelope *MessageEnvelope, opts ...TimerOption) CancelFunc {
+	if interval <= 0 {
+		panic(errors.New("actor: non-positive interval for SendRepeatedly"))
+	}
+
+	config := newTimerConfig(opts...)
+	id, t, ok := s.add()
+	if !ok {
+		return func() {}
+	}
+
+	t.mu.Lock()
+	t.timer = s.actorSystem.Config.Clock.AfterFunc(initial, func() {
+		if t.isCancelled() {
+			return
+		}
+
+		s.send(pid, envelope, config)
+
+		t.mu.Lock()
+		if !t.cancelled {
+			t.timer.Reset(interval)
+		}
+		t.mu.Unlock()
+	})
+	t.mu.Unlock()
+
+	return s.cancelFunc(id, t)
+}
+
+// CancelAll cancels every pending send, further sends are ignored.
+func (s *Scheduler) CancelAll() {
+	s.mu.Lock()
+	timers := s.timers
+	s.timers = make(map[uint64]*scheduledTimer)
+	s.stopped = true
+	s.mu.Unlock()
+
+	for _, t := range timers {
+		t.cancel()
+	}
+}
+
+// Len returns the number of pending timers
+func (s *Scheduler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.timers)
+}
+
+func (s *Scheduler) add() (uint64, *scheduledTimer, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return 0, nil, false
+	}
+
+	s.nextID++
+	t := &scheduledTimer{}
+	s.timers[s.nextID] = t
+
+	return s.nextID, t, true
+}
+
+func (s *Scheduler) remove(id uint64) {
+	s.mu.Lock()
+	delete(s.timers, id)
+	s.mu.Unlock()
+}
+
+func (s *Scheduler) cancelFunc(id uint64, t *scheduledTimer) CancelFunc {
+	return func() {
+		t.cancel()
+		s.remove(id)
+	}
+}
+
+// send delivers a copy of envelope, so a repeated send never shares a header with a message already in flight.
+func (s *Scheduler) send(pid *PID, envelope *MessageEnvelope, config *timerConfig) {
+	msg := &MessageEnvelope{
+		Message: envelope.Message,
+		Sender:  envelope.Sender,
+	}
+	if envelope.Header != nil {
+		msg.Header = envelope.Header.ToMap()
+	}
+	if config.notInfluenceReceiveTimeout {
+		msg.SetHeader(NotInfluenceReceiveTimeoutHeader, "true")
+	}
+
+	pid.sendUserMessage(s.actorSystem, msg)
+}
+
+func newTimerConfig(opts ...TimerOption) *timerConfig {
+	config := &timerConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return config
+}
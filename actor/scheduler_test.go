@@ -0,0 +1,110 @@
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func spawnCollector(t *testing.T, events chan interface{}) *PID {
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Envelope().Message.(*tickMessage); ok {
+			events <- msg
+		}
+	}))
+	t.Cleanup(func() { rootContext.Stop(pid) })
+
+	return pid
+}
+
+func TestScheduler_SendOnce(t *testing.T) {
+	events := make(chan interface{}, 10)
+	pid := spawnCollector(t, events)
+
+	system.Scheduler.SendOnce(10*time.Millisecond, pid, WrapEnvelope(&tickMessage{n: 1}))
+	assert.Equal(t, &tickMessage{n: 1}, waitFor(t, events))
+
+	cancel := system.Scheduler.SendOnce(20*time.Millisecond, pid, WrapEnvelope(&tickMessage{n: 2}))
+	cancel()
+	select {
+	case e := <-events:
+		t.Fatalf("cancelled timer fired: %#v", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestScheduler_SendRepeatedly(t *testing.T) {
+	events := make(chan interface{}, 100)
+	pid := spawnCollector(t, events)
+
+	cancel := system.Scheduler.SendRepeatedly(time.Millisecond, 5*time.Millisecond, pid, WrapEnvelope(&tickMessage{n: 1}))
+	for i := 0; i < 3; i++ {
+		waitFor(t, events)
+	}
+	cancel()
+
+	// drain a tick that may have raced with cancel
+	time.Sleep(20 * time.Millisecond)
+	for len(events) > 0 {
+		<-events
+	}
+	select {
+	case e := <-events:
+		t.Fatalf("cancelled timer fired: %#v", e)
+	case <-time.After(30 * time.Millisecond):
+	}
+}
+
+func TestScheduler_SendRepeatedlyRejectsNonPositiveInterval(t *testing.T) {
+	pid := system.NewLocalPID("ticks")
+	scheduler := NewScheduler(system)
+	for _, interval := range []time.Duration{0, -time.Second} {
+		assert.Panics(t, func() {
+			scheduler.SendRepeatedly(time.Millisecond, interval, pid, WrapEnvelope(&tickMessage{}))
+		})
+	}
+	assert.Equal(t, 0, scheduler.Len())
+}
+
+func TestScheduler_CancelledWhenActorStops(t *testing.T) {
+	events := make(chan interface{}, 10)
+	target := spawnCollector(t, events)
+	schedulers := make(chan *Scheduler, 1)
+
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Envelope().Message.(*Started); ok {
+			ctx.Scheduler().SendRepeatedly(20*time.Millisecond, 20*time.Millisecond, target, WrapEnvelope(&tickMessage{n: 1}))
+			schedulers <- ctx.Scheduler()
+		}
+	}))
+
+	scheduler := waitFor(t, schedulers)
+	assert.Equal(t, 1, scheduler.Len())
+	assert.NoError(t, rootContext.StopFuture(pid).Wait())
+	assert.Equal(t, 0, scheduler.Len())
+
+	select {
+	case e := <-events:
+		t.Fatalf("timer of a stopped actor fired: %#v", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestScheduler_NotInfluenceReceiveTimeout(t *testing.T) {
+	timeouts := make(chan interface{}, 10)
+
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Envelope().Message.(type) {
+		case *Started:
+			ctx.SetReceiveTimeout(50 * time.Millisecond)
+			ctx.Scheduler().SendRepeatedly(5*time.Millisecond, 5*time.Millisecond, ctx.Self(), WrapEnvelope(&tickMessage{}),
+				WithNotInfluenceReceiveTimeout())
+		case *ReceiveTimeout:
+			timeouts <- ctx.Envelope().Message
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	assert.IsType(t, &ReceiveTimeout{}, waitFor(t, timeouts))
+}
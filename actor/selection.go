@@ -0,0 +1,84 @@
+package actor
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Lookup returns the local process at path, e.g. "gate/tcp_acceptor", an actor or any other registered process
+// such as a router. The path of an actor is its PID ID: the names of its ancestors and its own, separated by "/".
+func (as *ActorSystem) Lookup(actorPath string) (*PID, error) {
+	id := strings.Trim(actorPath, "/")
+	pid := as.NewLocalPID(id)
+
+	ref, ok := as.ProcessRegistry.Get(pid)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrActorNotFound, actorPath)
+	}
+	switch ref.(type) {
+	case *deadLetter, *futureRegistry:
+		// processes of the actor system, they have no path
+		return nil, fmt.Errorf("%w: %s", ErrActorNotFound, actorPath)
+	}
+
+	return pid, nil
+}
+
+// ActorSelection is a set of local actors matched by a path pattern.
+// The pattern uses the syntax of path.Match, so "*" does not cross a "/": "gate/agent*" matches the agents
+// spawned by the gate but not their children. The pattern is resolved every time the selection is used.
+type ActorSelection struct {
+	actorSystem *ActorSystem
+	pattern     string
+}
+
+// ActorSelection returns the selection of the actors matching pattern.
+func (as *ActorSystem) ActorSelection(pattern string) (*ActorSelection, error) {
+	pattern = strings.Trim(pattern, "/")
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("actor selection %q: %w", pattern, err)
+	}
+
+	return &ActorSelection{
+		actorSystem: as,
+		pattern:     pattern,
+	}, nil
+}
+
+// Pattern returns the path pattern of the selection
+func (s *ActorSelection) Pattern() string {
+	return s.pattern
+}
+
+// PIDs resolves the selection, it scans the whole ProcessRegistry.
+func (s *ActorSelection) PIDs() []*PID {
+	var pids []*PID
+	for _, pid := range s.actorSystem.ProcessRegistry.PIDs() {
+		// the pattern was validated by ActorSelection
+		if ok, _ := path.Match(s.pattern, pid.ID); ok {
+			pids = append(pids, pid)
+		}
+	}
+
+	return pids
+}
+
+// Send sends envelope to every actor of the selection, it returns ErrActorNotFound when none matches.
+func (s *ActorSelection) Send(envelope *MessageEnvelope) error {
+	pids := s.PIDs()
+	if len(pids) == 0 {
+		return fmt.Errorf("%w: %s", ErrActorNotFound, s.pattern)
+	}
+
+	// every target gets its own envelope, a middleware of one must not change the envelope of the others
+	for _, pid := range pids {
+		s.actorSystem.Root.Send(pid, &MessageEnvelope{
+			Header:  envelope.Header,
+			Message: envelope.Message,
+			Sender:  envelope.Sender,
+		})
+	}
+
+	return nil
+}
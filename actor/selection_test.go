@@ -0,0 +1,94 @@
+package actor
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func spawnGate(t *testing.T, as *ActorSystem, events chan string) *PID {
+	ready := make(chan struct{}, 1)
+	gate, err := as.Root.SpawnNamed(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Envelope().Message.(*Started); !ok {
+			return
+		}
+		for _, name := range []string{"agent1", "agent2", "tcp_acceptor"} {
+			ctx.SpawnNamed(PropsFromFunc(func(ctx Context) {
+				if _, ok := ctx.Envelope().Message.(*tickMessage); ok {
+					events <- ctx.Self().ID
+				}
+			}), name)
+		}
+		ready <- struct{}{}
+	}), "gate")
+	require.NoError(t, err)
+	waitFor(t, ready)
+
+	return gate
+}
+
+func TestActorSystem_Lookup(t *testing.T) {
+	as := NewActorSystem()
+	defer as.ShutdownWithTimeout(context.Background())
+	spawnGate(t, as, make(chan string, 10))
+
+	pid, err := as.Lookup("gate/agent1")
+	require.NoError(t, err)
+	assert.Equal(t, "gate/agent1", pid.ID)
+
+	pid, err = as.Lookup("/gate/")
+	require.NoError(t, err)
+	assert.Equal(t, "gate", pid.ID)
+
+	_, err = as.Lookup("gate/agent3")
+	assert.True(t, errors.Is(err, ErrActorNotFound))
+
+	_, err = as.Lookup(as.DeadLetter.pid.ID)
+	assert.True(t, errors.Is(err, ErrActorNotFound))
+	_, err = as.Lookup(as.futures.pid.ID)
+	assert.True(t, errors.Is(err, ErrActorNotFound))
+}
+
+func TestActorSelection_Send(t *testing.T) {
+	as := NewActorSystem()
+	defer as.ShutdownWithTimeout(context.Background())
+	events := make(chan string, 10)
+	spawnGate(t, as, events)
+
+	selection, err := as.ActorSelection("gate/agent*")
+	require.NoError(t, err)
+	require.NoError(t, selection.Send(WrapEnvelope(&tickMessage{n: 1})))
+
+	got := []string{waitFor(t, events), waitFor(t, events)}
+	sort.Strings(got)
+	assert.Equal(t, []string{"gate/agent1", "gate/agent2"}, got)
+
+	select {
+	case id := <-events:
+		t.Fatalf("unexpected message to %s", id)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestActorSelection_NotFound(t *testing.T) {
+	as := NewActorSystem()
+	defer as.ShutdownWithTimeout(context.Background())
+	spawnGate(t, as, make(chan string, 10))
+
+	selection, err := as.ActorSelection("*")
+	require.NoError(t, err)
+	assert.Len(t, selection.PIDs(), 1, "* does not cross a /")
+
+	selection, err = as.ActorSelection("service/*")
+	require.NoError(t, err)
+	assert.Empty(t, selection.PIDs())
+	assert.True(t, errors.Is(selection.Send(WrapEnvelope(&tickMessage{n: 1})), ErrActorNotFound))
+
+	_, err = as.ActorSelection("gate/[")
+	assert.Error(t, err)
+}
@@ -0,0 +1,133 @@
+package actor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// ShutdownStage is a group of actors stopped together by ActorSystem.ShutdownWithTimeout.
+type ShutdownStage struct {
+	Name string
+
+	// PIDs is called when the stage begins, so it sees the actors spawned while the previous stages stopped
+	PIDs func() []*PID
+
+	// Timeout bounds the wait of this stage, 0 waits until the deadline of the shutdown context
+	Timeout time.Duration
+}
+
+// ShutdownError lists the actors that did not terminate before their deadline and were force-stopped.
+type ShutdownError struct {
+	Unterminated []*PID
+}
+
+func (e *ShutdownError) Error() string {
+	ids := make([]string, 0, len(e.Unterminated))
+	for _, pid := range e.Unterminated {
+		ids = append(ids, pid.ID)
+	}
+
+	return fmt.Sprintf("actor system shutdown: %d actors did not terminate: %s", len(ids), strings.Join(ids, ", "))
+}
+
+// ShutdownWithTimeout stops the actors stage by stage, then the remaining top level actors, and shuts the system down.
+//
+// Each stage poisons its actors, so they process the messages already in their mailbox, and waits for them.
+// Actors still alive when the stage times out or ctx is done are stopped and removed from the ProcessRegistry,
+// so one stuck actor cannot hang the shutdown; they are reported in the returned *ShutdownError.
+func (as *ActorSystem) ShutdownWithTimeout(ctx context.Context, stages ...ShutdownStage) error {
+	var unterminated []*PID
+	for _, stage := range stages {
+		unterminated = append(unterminated, as.stopStage(ctx, stage)...)
+	}
+
+	unterminated = append(unterminated, as.stopStage(ctx, ShutdownStage{
+		Name: "remaining",
+		PIDs: as.ProcessRegistry.topLevelPIDs,
+	})...)
+
+	as.Scheduler.CancelAll()
+	as.ProcessRegistry.Remove(as.DeadLetter.pid)
+	as.ProcessRegistry.Remove(as.futures.pid)
+	if !as.ProcessRegistry.wait(ctx) {
+		// children of force-stopped actors
+		for _, pid := range as.ProcessRegistry.processPIDs() {
+			as.ProcessRegistry.Remove(pid)
+			unterminated = append(unterminated, pid)
+		}
+	}
+	close(as.stopper)
+
+	if len(unterminated) > 0 {
+		return &ShutdownError{Unterminated: unterminated}
+	}
+
+	return nil
+}
+
+type stageResult struct {
+	index      int
+	terminated bool
+}
+
+func (as *ActorSystem) stopStage(ctx context.Context, stage ShutdownStage) []*PID {
+	pids := stage.PIDs()
+	if len(pids) == 0 {
+		return nil
+	}
+
+	if stage.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, stage.Timeout)
+		defer cancel()
+	}
+
+	timeout := time.Duration(-1)
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = max(time.Until(deadline), 0)
+	}
+
+	as.Logger().Info("actor system stopping stage", slog.String("stage", stage.Name), slog.Int("actors", len(pids)))
+
+	results := make(chan stageResult, len(pids))
+	futures := make([]*Future, len(pids))
+	for i, pid := range pids {
+		i := i
+		futures[i] = NewFuture(as, timeout)
+		futures[i].ContinueWith(func(res *MessageEnvelope, err error) {
+			results <- stageResult{index: i, terminated: err == nil}
+		})
+		pid.sendSystemMessage(as, &Watch{Watcher: futures[i].pid})
+		as.Root.Poison(pid)
+	}
+
+	terminated := make([]bool, len(pids))
+wait:
+	for remaining := len(pids); remaining > 0; remaining-- {
+		select {
+		case r := <-results:
+			terminated[r.index] = r.terminated
+		case <-ctx.Done():
+			break wait
+		}
+	}
+
+	var unterminated []*PID
+	for i, pid := range pids {
+		if terminated[i] {
+			continue
+		}
+
+		as.Logger().Warn("actor did not terminate, force stopping",
+			slog.String("stage", stage.Name), slog.String("pid", pid.String()))
+		as.Root.Stop(pid)
+		as.ProcessRegistry.Remove(pid)
+		as.futures.Stop(futures[i].pid)
+		unterminated = append(unterminated, pid)
+	}
+
+	return unterminated
+}
@@ -0,0 +1,76 @@
+package actor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActorSystem_ShutdownWithTimeoutStopsStagesInOrder(t *testing.T) {
+	as := NewActorSystem()
+	stopped := make(chan string, 10)
+	spawn := func(name string) *PID {
+		pid, err := as.Root.SpawnNamed(PropsFromFunc(func(ctx Context) {
+			if _, ok := ctx.Envelope().Message.(*Stopped); ok {
+				stopped <- name
+			}
+		}), name)
+		require.NoError(t, err)
+		return pid
+	}
+
+	services := spawn("service")
+	gate := spawn("gate")
+	spawn("other")
+
+	err := as.ShutdownWithTimeout(context.Background(),
+		ShutdownStage{Name: "gate", PIDs: func() []*PID { return []*PID{gate} }},
+		ShutdownStage{Name: "services", PIDs: func() []*PID { return []*PID{services} }},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "gate", <-stopped)
+	assert.Equal(t, "service", <-stopped)
+	assert.Equal(t, "other", <-stopped)
+	assert.True(t, as.IsStopped())
+	assert.Empty(t, as.ProcessRegistry.PIDs())
+}
+
+func TestActorSystem_ShutdownWithTimeoutForceStopsStuckActor(t *testing.T) {
+	as := NewActorSystem()
+	release := make(chan struct{})
+	defer close(release)
+
+	stuck := as.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Envelope().Message.(*blockMessage); ok {
+			<-release
+		}
+	}))
+	as.Root.Send(stuck, WrapEnvelope(&blockMessage{}))
+	fine := as.Root.Spawn(PropsFromFunc(func(ctx Context) {}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	err := as.ShutdownWithTimeout(ctx, ShutdownStage{
+		Name:    "stuck",
+		PIDs:    func() []*PID { return []*PID{stuck, fine} },
+		Timeout: 50 * time.Millisecond,
+	})
+
+	var shutdownErr *ShutdownError
+	require.ErrorAs(t, err, &shutdownErr)
+	assert.Equal(t, []*PID{stuck}, shutdownErr.Unterminated)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.True(t, as.IsStopped())
+}
+
+func TestProcessRegistry_RemoveTwice(t *testing.T) {
+	as := NewActorSystem()
+	pid := as.Root.Spawn(PropsFromFunc(func(ctx Context) {}))
+
+	as.ProcessRegistry.Remove(pid)
+	assert.NotPanics(t, func() { as.ProcessRegistry.Remove(pid) })
+}
@@ -0,0 +1,87 @@
+package actor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type readyMessage struct{}
+
+// loadingActor stashes the ticks until it is ready, like an actor loading its state
+type loadingActor struct {
+	ready  bool
+	events chan interface{}
+	errs   chan error
+}
+
+func (a *loadingActor) Receive(ctx Context) {
+	switch msg := ctx.Envelope().Message.(type) {
+	case *readyMessage:
+		a.ready = true
+		ctx.UnstashAll()
+	case *failMessage:
+		panic(errChildFailed)
+	case *tickMessage:
+		if !a.ready {
+			if err := ctx.Stash(); err != nil {
+				a.errs <- err
+			}
+			return
+		}
+		a.events <- msg
+	}
+}
+
+func spawnLoadingActor(t *testing.T, opts ...PropsOption) (*PID, chan interface{}, chan error) {
+	events, errs := make(chan interface{}, 10), make(chan error, 10)
+	pid := rootContext.Spawn(PropsFromProducer(func() Actor {
+		return &loadingActor{events: events, errs: errs}
+	}, opts...))
+	t.Cleanup(func() { rootContext.Stop(pid) })
+
+	return pid, events, errs
+}
+
+func TestStash_UnstashAllReplaysInOrder(t *testing.T) {
+	pid, events, _ := spawnLoadingActor(t)
+
+	for i := 1; i <= 3; i++ {
+		rootContext.Send(pid, WrapEnvelope(&tickMessage{n: i}))
+	}
+	rootContext.Send(pid, WrapEnvelope(&readyMessage{}))
+	rootContext.Send(pid, WrapEnvelope(&tickMessage{n: 4}))
+
+	// the stashed messages come before the ones already waiting in the mailbox
+	for i := 1; i <= 4; i++ {
+		assert.Equal(t, &tickMessage{n: i}, waitFor(t, events))
+	}
+}
+
+func TestStash_Capacity(t *testing.T) {
+	pid, events, errs := spawnLoadingActor(t, WithStashCapacity(2))
+
+	for i := 1; i <= 3; i++ {
+		rootContext.Send(pid, WrapEnvelope(&tickMessage{n: i}))
+	}
+	assert.Equal(t, ErrStashFull, waitFor(t, errs))
+
+	rootContext.Send(pid, WrapEnvelope(&readyMessage{}))
+	assert.Equal(t, &tickMessage{n: 1}, waitFor(t, events))
+	assert.Equal(t, &tickMessage{n: 2}, waitFor(t, events))
+}
+
+func TestStash_SurvivesRestart(t *testing.T) {
+	pid, events, _ := spawnLoadingActor(t)
+
+	rootContext.Send(pid, WrapEnvelope(&tickMessage{n: 1}))
+	rootContext.Send(pid, WrapEnvelope(&tickMessage{n: 2}))
+	rootContext.Send(pid, WrapEnvelope(&failMessage{}))
+	// the new incarnation is not ready either and stashes the replayed messages again
+	rootContext.Send(pid, WrapEnvelope(&tickMessage{n: 3}))
+	rootContext.Send(pid, WrapEnvelope(&readyMessage{}))
+
+	for i := 1; i <= 3; i++ {
+		assert.Equal(t, &tickMessage{n: i}, waitFor(t, events))
+	}
+}
@@ -0,0 +1,63 @@
+package actor
+
+import (
+	"math/rand"
+	"time"
+)
+
+// maxBackoffShift caps the exponent so the backoff duration can never overflow.
+const maxBackoffShift = 30
+
+type exponentialBackoffStrategy struct {
+	backoffWindow  time.Duration
+	initialBackoff time.Duration
+}
+
+var _ SupervisorStrategy = &exponentialBackoffStrategy{}
+
+// NewExponentialBackoffStrategy creates a new Supervisor strategy that restarts a faulting child using an exponential
+// back off algorithm:
+//
+//	delay = initialBackoff * 2^(failures-1) + jitter, capped at backoffWindow
+//
+// The failure count is reset once the child has not failed for backoffWindow.
+func NewExponentialBackoffStrategy(backoffWindow time.Duration, initialBackoff time.Duration) SupervisorStrategy {
+	return &exponentialBackoffStrategy{
+		backoffWindow:  backoffWindow,
+		initialBackoff: initialBackoff,
+	}
+}
+
+func (strategy *exponentialBackoffStrategy) HandleFailure(actorSystem *ActorSystem, supervisor Supervisor, child *PID, rs *RestartStatistics, reason interface{}, _ interface{}) {
+	strategy.setFailureCount(rs)
+
+	logFailure(actorSystem, child, reason, RestartDirective)
+	time.AfterFunc(strategy.backoff(rs.FailureCount()), func() {
+		supervisor.RestartChildren(child)
+	})
+}
+
+func (strategy *exponentialBackoffStrategy) setFailureCount(rs *RestartStatistics) {
+	// if we are within the backoff window, exit early
+	if rs.NumberOfFailures(strategy.backoffWindow) == 0 {
+		rs.Reset()
+	}
+
+	rs.Fail()
+}
+
+func (strategy *exponentialBackoffStrategy) backoff(failures int) time.Duration {
+	shift := failures - 1
+	if shift > maxBackoffShift {
+		shift = maxBackoffShift
+	}
+
+	backoff := strategy.initialBackoff << uint(shift)
+	if strategy.backoffWindow > 0 && backoff > strategy.backoffWindow {
+		backoff = strategy.backoffWindow
+	}
+
+	noise := time.Duration(rand.Int63n(int64(500 * time.Microsecond)))
+
+	return backoff + noise
+}
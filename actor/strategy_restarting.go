@@ -0,0 +1,17 @@
+package actor
+
+type restartingStrategy struct{}
+
+var _ SupervisorStrategy = &restartingStrategy{}
+
+// NewRestartingStrategy returns a SupervisorStrategy which restarts the failing child
+// every time, without any limit on the number of restarts.
+func NewRestartingStrategy() SupervisorStrategy {
+	return &restartingStrategy{}
+}
+
+func (strategy *restartingStrategy) HandleFailure(actorSystem *ActorSystem, supervisor Supervisor, child *PID, _ *RestartStatistics, reason interface{}, _ interface{}) {
+	// always restart
+	logFailure(actorSystem, child, reason, RestartDirective)
+	supervisor.RestartChildren(child)
+}
@@ -0,0 +1,91 @@
+package actor
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Directive is the decision a SupervisorStrategy takes for a failed child
+type Directive int
+
+const (
+	// ResumeDirective instructs the supervisor to resume the actor and continue processing messages
+	ResumeDirective Directive = iota
+
+	// RestartDirective instructs the supervisor to discard the actor, replacing it with a new instance
+	RestartDirective
+
+	// StopDirective instructs the supervisor to stop the actor
+	StopDirective
+
+	// EscalateDirective instructs the supervisor to escalate handling of the failure to its own supervisor
+	EscalateDirective
+)
+
+func (d Directive) String() string {
+	switch d {
+	case ResumeDirective:
+		return "ResumeDirective"
+	case RestartDirective:
+		return "RestartDirective"
+	case StopDirective:
+		return "StopDirective"
+	case EscalateDirective:
+		return "EscalateDirective"
+	default:
+		return "UnknownDirective"
+	}
+}
+
+// DeciderFunc is a function which is called by a SupervisorStrategy
+type DeciderFunc func(reason interface{}) Directive
+
+// Supervisor is an interface that is used by the SupervisorStrategy to manage child actor lifecycle
+type Supervisor interface {
+	Children() []*PID
+	EscalateFailure(reason interface{}, message interface{})
+	RestartChildren(pids ...*PID)
+	StopChildren(pids ...*PID)
+	ResumeChildren(pids ...*PID)
+}
+
+// SupervisorStrategy is an interface that decides how to handle failing child actors
+type SupervisorStrategy interface {
+	HandleFailure(actorSystem *ActorSystem, supervisor Supervisor, child *PID, rs *RestartStatistics, reason interface{}, message interface{})
+}
+
+// SupervisorEvent is sent on the EventStream when a supervisor have applied a directive to a failing child actor
+type SupervisorEvent struct {
+	Child     *PID
+	Reason    interface{}
+	Directive Directive
+}
+
+var _ EventMessage = &SupervisorEvent{}
+
+func (*SupervisorEvent) EventMessage() {}
+
+// DefaultDecider is a decider that will always restart the failing child actor
+func DefaultDecider(_ interface{}) Directive {
+	return RestartDirective
+}
+
+var defaultSupervisionStrategy = NewOneForOneStrategy(10, 10*time.Second, DefaultDecider)
+
+// DefaultSupervisorStrategy returns the strategy used when Props does not configure one.
+func DefaultSupervisorStrategy() SupervisorStrategy {
+	return defaultSupervisionStrategy
+}
+
+func logFailure(actorSystem *ActorSystem, child *PID, reason interface{}, directive Directive) {
+	actorSystem.Logger().Warn("actor failure",
+		slog.String("child", child.String()),
+		slog.Any("reason", reason),
+		slog.String("directive", directive.String()))
+
+	actorSystem.EventStream.Publish(&SupervisorEvent{
+		Child:     child,
+		Reason:    reason,
+		Directive: directive,
+	})
+}
@@ -0,0 +1,202 @@
+package actor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failMessage struct{}
+
+type pingMessage struct{}
+
+var errChildFailed = errors.New("child failed")
+
+func waitFor[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for message")
+	}
+	var zero T
+	return zero
+}
+
+func TestSupervision_DefaultStrategyRestartsRootActor(t *testing.T) {
+	events := make(chan interface{}, 10)
+	props := PropsFromFunc(func(ctx Context) {
+		switch ctx.Envelope().Message.(type) {
+		case *Started, *Restarting:
+			events <- ctx.Envelope().Message
+		case *failMessage:
+			panic(errChildFailed)
+		case *pingMessage:
+			events <- ctx.Envelope().Message
+		}
+	})
+
+	pid := rootContext.Spawn(props)
+	defer rootContext.Stop(pid)
+
+	assert.IsType(t, &Started{}, waitFor(t, events))
+	rootContext.Send(pid, WrapEnvelope(&failMessage{}))
+	assert.IsType(t, &Restarting{}, waitFor(t, events))
+	assert.IsType(t, &Started{}, waitFor(t, events))
+
+	rootContext.Send(pid, WrapEnvelope(&pingMessage{}))
+	assert.IsType(t, &pingMessage{}, waitFor(t, events))
+}
+
+func TestSupervision_StopDirectiveTerminatesChild(t *testing.T) {
+	terminated := make(chan *Terminated, 1)
+	childProps := PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Envelope().Message.(*failMessage); ok {
+			panic(errChildFailed)
+		}
+	})
+
+	strategy := NewOneForOneStrategy(10, time.Second, func(reason interface{}) Directive {
+		return StopDirective
+	})
+	parentProps := PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Envelope().Message.(type) {
+		case *Started:
+			child := ctx.Spawn(childProps)
+			ctx.Send(child, WrapEnvelope(&failMessage{}))
+		case *Terminated:
+			terminated <- msg
+		}
+	}, WithSupervisor(strategy))
+
+	parent := rootContext.Spawn(parentProps)
+	defer rootContext.Stop(parent)
+
+	msg := waitFor(t, terminated)
+	assert.Equal(t, TerminatedReason_Stopped, msg.Why)
+}
+
+func TestSupervision_ResumeDirectiveKeepsState(t *testing.T) {
+	counts := make(chan int, 10)
+	childProps := PropsFromProducer(func() Actor {
+		count := 0
+		return ReceiveFunc(func(ctx Context) {
+			switch ctx.Envelope().Message.(type) {
+			case *failMessage:
+				count++
+				panic(errChildFailed)
+			case *pingMessage:
+				count++
+				counts <- count
+			}
+		})
+	})
+
+	strategy := NewOneForOneStrategy(10, time.Second, func(reason interface{}) Directive {
+		return ResumeDirective
+	})
+	parentProps := PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Envelope().Message.(*Started); ok {
+			child := ctx.Spawn(childProps)
+			ctx.Send(child, WrapEnvelope(&failMessage{}))
+			ctx.Send(child, WrapEnvelope(&pingMessage{}))
+		}
+	}, WithSupervisor(strategy))
+
+	parent := rootContext.Spawn(parentProps)
+	defer rootContext.Stop(parent)
+
+	assert.Equal(t, 2, waitFor(t, counts))
+}
+
+func TestSupervision_AllForOneRestartsSiblings(t *testing.T) {
+	restarts := make(chan *PID, 10)
+	childProps := PropsFromFunc(func(ctx Context) {
+		switch ctx.Envelope().Message.(type) {
+		case *Restarting:
+			restarts <- ctx.Self()
+		case *failMessage:
+			panic(errChildFailed)
+		}
+	})
+
+	strategy := NewAllForOneStrategy(10, time.Second, DefaultDecider)
+	parentProps := PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Envelope().Message.(*Started); ok {
+			first := ctx.Spawn(childProps)
+			ctx.Spawn(childProps)
+			ctx.Send(first, WrapEnvelope(&failMessage{}))
+		}
+	}, WithSupervisor(strategy))
+
+	parent := rootContext.Spawn(parentProps)
+	defer rootContext.Stop(parent)
+
+	first := waitFor(t, restarts)
+	second := waitFor(t, restarts)
+	assert.False(t, first.Equal(second))
+}
+
+func TestSupervision_EscalateDirectiveReachesGrandparent(t *testing.T) {
+	failures := make(chan interface{}, 1)
+	childProps := PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Envelope().Message.(*failMessage); ok {
+			panic(errChildFailed)
+		}
+	})
+
+	escalate := NewOneForOneStrategy(10, time.Second, func(reason interface{}) Directive {
+		return EscalateDirective
+	})
+	record := NewOneForOneStrategy(10, time.Second, func(reason interface{}) Directive {
+		failures <- reason
+		return StopDirective
+	})
+
+	parentProps := PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Envelope().Message.(*Started); ok {
+			child := ctx.Spawn(childProps)
+			ctx.Send(child, WrapEnvelope(&failMessage{}))
+		}
+	}, WithSupervisor(escalate))
+	grandparentProps := PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Envelope().Message.(*Started); ok {
+			ctx.Spawn(parentProps)
+		}
+	}, WithSupervisor(record))
+
+	grandparent := rootContext.Spawn(grandparentProps)
+	defer rootContext.Stop(grandparent)
+
+	assert.Equal(t, errChildFailed, waitFor(t, failures))
+}
+
+func TestOneForOne_StopsAfterMaxRetries(t *testing.T) {
+	rs := NewRestartStatistics()
+	assert.False(t, shouldStop(rs, 2, time.Second))
+	assert.False(t, shouldStop(rs, 2, time.Second))
+	assert.True(t, shouldStop(rs, 2, time.Second))
+	assert.Equal(t, 0, rs.FailureCount())
+}
+
+func TestRestartStatistics_NumberOfFailures(t *testing.T) {
+	rs := NewRestartStatistics()
+	rs.failureTimes = append(rs.failureTimes, time.Now().Add(-time.Minute))
+	rs.Fail()
+
+	assert.Equal(t, 2, rs.FailureCount())
+	assert.Equal(t, 1, rs.NumberOfFailures(time.Second))
+	assert.Equal(t, 2, rs.NumberOfFailures(0))
+}
+
+func TestExponentialBackoff_Doubles(t *testing.T) {
+	s := NewExponentialBackoffStrategy(time.Second, 10*time.Millisecond).(*exponentialBackoffStrategy)
+
+	assert.InDelta(t, 10*time.Millisecond, s.backoff(1), float64(time.Millisecond))
+	assert.InDelta(t, 20*time.Millisecond, s.backoff(2), float64(time.Millisecond))
+	assert.InDelta(t, 40*time.Millisecond, s.backoff(3), float64(time.Millisecond))
+	assert.InDelta(t, time.Second, s.backoff(20), float64(time.Millisecond))
+}
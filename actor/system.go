@@ -12,8 +12,11 @@ type ActorSystem struct {
 	Root            *RootContext
 	EventStream     *EventStream
 	DeadLetter      *deadLetter
+	futures         *futureRegistry
+	Scheduler       *Scheduler
 	Config          *Config
 	logger          *slog.Logger
+	mailboxStats    mailboxStatsRegistry
 
 	ID      string
 	stopper chan struct{}
@@ -24,16 +27,19 @@ func NewActorSystem(opts ...ConfigOption) *ActorSystem {
 	return NewActorSystemWithConfig(config)
 }
 
+// NewActorSystemWithConfig creates an actor system, the fields config leaves unset get their default value.
 func NewActorSystemWithConfig(config *Config) *ActorSystem {
 	actorSystem := new(ActorSystem)
-	actorSystem.Config = config
+	actorSystem.Config = config.withDefaults()
 	actorSystem.ID = shortuuid.New()
 	actorSystem.stopper = make(chan struct{}, 1)
-	actorSystem.logger = config.LoggerFactory(actorSystem)
+	actorSystem.logger = actorSystem.Config.LoggerFactory(actorSystem)
 	actorSystem.ProcessRegistry = NewProcessRegistry(actorSystem)
 	actorSystem.Root = NewRootContext(actorSystem, EmptyMessageHeader)
 	actorSystem.EventStream = NewEventStream()
 	actorSystem.DeadLetter = newDeadLetter(actorSystem)
+	actorSystem.futures = newFutureRegistry(actorSystem)
+	actorSystem.Scheduler = NewScheduler(actorSystem)
 
 	return actorSystem
 }
@@ -47,7 +53,9 @@ func (as *ActorSystem) Address() string {
 }
 
 func (as *ActorSystem) Shutdown() {
+	as.Scheduler.CancelAll()
 	as.ProcessRegistry.Remove(as.DeadLetter.pid)
+	as.ProcessRegistry.Remove(as.futures.pid)
 	as.ProcessRegistry.shutdown()
 	close(as.stopper)
 }
@@ -61,6 +69,16 @@ func (as *ActorSystem) IsStopped() bool {
 	}
 }
 
+// MailboxStats returns the mailbox statistics of an actor spawned with WithMailboxStats.
+func (as *ActorSystem) MailboxStats(pid *PID) (MailboxStats, bool) {
+	return as.mailboxStats.get(pid)
+}
+
+// AllMailboxStats returns the mailbox statistics of every live actor spawned with WithMailboxStats.
+func (as *ActorSystem) AllMailboxStats() []MailboxStats {
+	return as.mailboxStats.all()
+}
+
 func (as *ActorSystem) Logger() *slog.Logger {
 	return as.logger
 }
@@ -0,0 +1,126 @@
+package testkit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/colin1989/battery/actor"
+)
+
+// VirtualClock is an actor.Clock whose time only moves when Advance is called.
+//
+// Timers fire in the goroutine calling Advance, in deadline order, so actors running on a synchronized
+// dispatcher have processed the ReceiveTimeout, future timeout or scheduled message when Advance returns.
+type VirtualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	seq    uint64
+	timers []*virtualTimer
+}
+
+var _ actor.Clock = &VirtualClock{}
+
+// NewVirtualClock creates a clock starting at now
+func NewVirtualClock(now time.Time) *VirtualClock {
+	return &VirtualClock{now: now}
+}
+
+func (c *VirtualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *VirtualClock) AfterFunc(d time.Duration, f func()) actor.Timer {
+	t := &virtualTimer{clock: c, f: f}
+	t.Reset(d)
+
+	return t
+}
+
+// Advance moves the clock forward by d and fires the timers due meanwhile, including the ones they schedule.
+func (c *VirtualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	c.mu.Unlock()
+
+	for {
+		c.mu.Lock()
+		t := c.next(target)
+		if t == nil {
+			c.now = target
+			c.mu.Unlock()
+
+			return
+		}
+		c.remove(t)
+		if t.deadline.After(c.now) {
+			c.now = t.deadline
+		}
+		c.mu.Unlock()
+
+		t.f()
+	}
+}
+
+// Pending returns the number of timers waiting to fire
+func (c *VirtualClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.timers)
+}
+
+// next returns the earliest timer due at target, timers with the same deadline fire in the order they were set
+func (c *VirtualClock) next(target time.Time) *virtualTimer {
+	var next *virtualTimer
+	for _, t := range c.timers {
+		if t.deadline.After(target) {
+			continue
+		}
+		if next == nil || t.deadline.Before(next.deadline) || (t.deadline.Equal(next.deadline) && t.seq < next.seq) {
+			next = t
+		}
+	}
+
+	return next
+}
+
+func (c *VirtualClock) remove(t *virtualTimer) bool {
+	for i, timer := range c.timers {
+		if timer == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+type virtualTimer struct {
+	clock    *VirtualClock
+	f        func()
+	deadline time.Time
+	seq      uint64
+}
+
+func (t *virtualTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	return t.clock.remove(t)
+}
+
+func (t *virtualTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	active := t.clock.remove(t)
+	t.clock.seq++
+	t.seq = t.clock.seq
+	t.deadline = t.clock.now.Add(d)
+	t.clock.timers = append(t.clock.timers, t)
+
+	return active
+}
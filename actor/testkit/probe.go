@@ -0,0 +1,196 @@
+package testkit
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/colin1989/battery/actor"
+)
+
+// DefaultExpectTimeout is how long a TestProbe waits for a message, in wall clock time.
+const DefaultExpectTimeout = 3 * time.Second
+
+// watchRequest makes the probe watch an actor from its own context
+type watchRequest struct {
+	pid *actor.PID
+}
+
+// TestProbe is an actor recording the messages it receives, so a test can assert on them in order.
+// Lifecycle messages of the probe itself (Started, Stopping...) are not recorded.
+type TestProbe struct {
+	t       testing.TB
+	system  *actor.ActorSystem
+	pid     *actor.PID
+	timeout time.Duration
+
+	mu       sync.Mutex
+	messages []*actor.MessageEnvelope
+	notify   chan struct{}
+	last     *actor.MessageEnvelope
+}
+
+// ProbeOption configures a TestProbe
+type ProbeOption func(probe *TestProbe)
+
+// WithExpectTimeout sets how long the Expect methods wait for a message
+func WithExpectTimeout(timeout time.Duration) ProbeOption {
+	return func(probe *TestProbe) {
+		probe.timeout = timeout
+	}
+}
+
+// NewProbe spawns a probe in system
+func NewProbe(t testing.TB, system *actor.ActorSystem, opts ...ProbeOption) *TestProbe {
+	probe := &TestProbe{
+		t:       t,
+		system:  system,
+		timeout: DefaultExpectTimeout,
+		notify:  make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(probe)
+	}
+
+	probe.pid = system.Root.SpawnPrefix(actor.PropsFromFunc(probe.receive,
+		actor.WithDispatcher(actor.NewSynchronizedDispatcher(300))), "probe")
+
+	return probe
+}
+
+func (p *TestProbe) receive(ctx actor.Context) {
+	switch msg := ctx.Envelope().Message.(type) {
+	case *actor.Started, *actor.Stopping, *actor.Stopped, *actor.Restarting:
+	case *watchRequest:
+		ctx.Watch(msg.pid)
+	default:
+		p.mu.Lock()
+		p.messages = append(p.messages, ctx.Envelope())
+		p.mu.Unlock()
+
+		select {
+		case p.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// PID of the probe, use it as the sender or the target of the actors under test
+func (p *TestProbe) PID() *actor.PID {
+	return p.pid
+}
+
+// Send sends message to pid with the probe as the sender, so the replies are recorded by the probe.
+func (p *TestProbe) Send(pid *actor.PID, message interface{}) {
+	p.system.Root.Send(pid, actor.WrapEnvelopWithSender(message, p.pid))
+}
+
+// Reply answers the sender of the last message received by the probe
+func (p *TestProbe) Reply(message interface{}) {
+	p.t.Helper()
+	if p.last == nil || p.last.Sender == nil {
+		p.t.Fatal("testkit: the last message received by the probe has no sender")
+		return
+	}
+
+	p.Send(p.last.Sender, message)
+}
+
+// Watch makes the probe receive the Terminated message of pid, see ExpectTerminated.
+func (p *TestProbe) Watch(pid *actor.PID) {
+	p.system.Root.Send(p.pid, actor.WrapEnvelope(&watchRequest{pid: pid}))
+}
+
+// ExpectMsg fails the test unless the next message equals expected.
+func (p *TestProbe) ExpectMsg(expected interface{}) *actor.MessageEnvelope {
+	p.t.Helper()
+	envelope := p.expectNext(fmt.Sprintf("%T", expected))
+	if envelope == nil {
+		return nil
+	}
+	if !reflect.DeepEqual(expected, envelope.Message) {
+		p.t.Fatalf("testkit: expected message %#v, got %#v", expected, envelope.Message)
+	}
+
+	return envelope
+}
+
+// ExpectNoMsg fails the test if a message is received within d.
+func (p *TestProbe) ExpectNoMsg(d time.Duration) {
+	p.t.Helper()
+	if envelope, ok := p.next(d); ok {
+		p.t.Fatalf("testkit: expected no message, got %#v", envelope.Message)
+	}
+}
+
+// ExpectTerminated fails the test unless the next message is the Terminated of pid, the probe must Watch pid.
+func (p *TestProbe) ExpectTerminated(pid *actor.PID) *actor.Terminated {
+	p.t.Helper()
+	envelope := p.expectNext("*actor.Terminated")
+	if envelope == nil {
+		return nil
+	}
+
+	terminated, ok := envelope.Message.(*actor.Terminated)
+	if !ok || !terminated.Who.Equal(pid) {
+		p.t.Fatalf("testkit: expected Terminated of %s, got %#v", pid, envelope.Message)
+		return nil
+	}
+
+	return terminated
+}
+
+// FishForMessage discards the messages until fn accepts one and returns it,
+// it fails the test if none is accepted within the expect timeout.
+func (p *TestProbe) FishForMessage(fn func(message interface{}) bool) *actor.MessageEnvelope {
+	p.t.Helper()
+	deadline := time.Now().Add(p.timeout)
+	for {
+		envelope, ok := p.next(time.Until(deadline))
+		if !ok {
+			p.t.Fatalf("testkit: timed out after %s fishing for a message", p.timeout)
+			return nil
+		}
+		if fn(envelope.Message) {
+			return envelope
+		}
+	}
+}
+
+func (p *TestProbe) expectNext(expected string) *actor.MessageEnvelope {
+	p.t.Helper()
+	envelope, ok := p.next(p.timeout)
+	if !ok {
+		p.t.Fatalf("testkit: timed out after %s waiting for %s", p.timeout, expected)
+		return nil
+	}
+
+	return envelope
+}
+
+// next pops the oldest message, waiting at most timeout for one to arrive
+func (p *TestProbe) next(timeout time.Duration) (*actor.MessageEnvelope, bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		p.mu.Lock()
+		if len(p.messages) > 0 {
+			envelope := p.messages[0]
+			p.messages = p.messages[1:]
+			p.last = envelope
+			p.mu.Unlock()
+
+			return envelope, true
+		}
+		p.mu.Unlock()
+
+		select {
+		case <-p.notify:
+		case <-timer.C:
+			return nil, false
+		}
+	}
+}
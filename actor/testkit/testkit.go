@@ -0,0 +1,69 @@
+// Package testkit runs actors deterministically in tests: the actors are spawned on a synchronized dispatcher,
+// so a send returns once the message is processed, and time is a VirtualClock advanced by the test.
+package testkit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/colin1989/battery/actor"
+)
+
+// shutdownTimeout bounds the shutdown of the actor system when the test ends
+const shutdownTimeout = time.Second
+
+// TestKit owns an actor system driven by a VirtualClock, the system is shut down when the test ends.
+type TestKit struct {
+	t      testing.TB
+	System *actor.ActorSystem
+	Clock  *VirtualClock
+}
+
+// New creates a TestKit, opts are applied after the virtual clock and may replace it.
+func New(t testing.TB, opts ...actor.ConfigOption) *TestKit {
+	clock := NewVirtualClock(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC))
+	system := actor.NewActorSystem(append([]actor.ConfigOption{actor.WithClock(clock)}, opts...)...)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = system.ShutdownWithTimeout(ctx)
+	})
+
+	return &TestKit{
+		t:      t,
+		System: system,
+		Clock:  clock,
+	}
+}
+
+// Spawn spawns props on a synchronized dispatcher, props is modified.
+func (tk *TestKit) Spawn(props *actor.Props) *actor.PID {
+	return tk.System.Root.Spawn(synchronized(props))
+}
+
+// SpawnNamed spawns props on a synchronized dispatcher, props is modified.
+func (tk *TestKit) SpawnNamed(props *actor.Props, name string) *actor.PID {
+	tk.t.Helper()
+	pid, err := tk.System.Root.SpawnNamed(synchronized(props), name)
+	if err != nil {
+		tk.t.Fatalf("testkit: spawn %s: %v", name, err)
+	}
+
+	return pid
+}
+
+// NewProbe spawns a TestProbe in the actor system of the kit
+func (tk *TestKit) NewProbe(opts ...ProbeOption) *TestProbe {
+	return NewProbe(tk.t, tk.System, opts...)
+}
+
+// Advance moves the virtual clock forward, see VirtualClock.Advance.
+func (tk *TestKit) Advance(d time.Duration) {
+	tk.Clock.Advance(d)
+}
+
+func synchronized(props *actor.Props) *actor.Props {
+	return props.Configure(actor.WithDispatcher(actor.NewSynchronizedDispatcher(300)))
+}
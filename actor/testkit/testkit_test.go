@@ -0,0 +1,118 @@
+package testkit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/colin1989/battery/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+type ping struct{ n int }
+
+type pong struct{ n int }
+
+type tick struct{}
+
+func TestProbe_ExpectMsg(t *testing.T) {
+	tk := New(t)
+	probe := tk.NewProbe()
+	echo := tk.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Envelope().Message.(*ping); ok {
+			ctx.Respond(actor.WrapEnvelope(&pong{n: msg.n}))
+		}
+	}))
+
+	probe.Send(echo, &ping{n: 1})
+	probe.Send(echo, &ping{n: 2})
+	probe.ExpectMsg(&pong{n: 1})
+	probe.ExpectMsg(&pong{n: 2})
+	probe.ExpectNoMsg(10 * time.Millisecond)
+}
+
+func TestProbe_ExpectTerminated(t *testing.T) {
+	tk := New(t)
+	probe := tk.NewProbe()
+	pid := tk.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {}))
+
+	probe.Watch(pid)
+	tk.System.Root.Stop(pid)
+	probe.ExpectTerminated(pid)
+}
+
+func TestProbe_FishForMessage(t *testing.T) {
+	tk := New(t)
+	probe := tk.NewProbe()
+	for i := 1; i <= 3; i++ {
+		tk.System.Root.Send(probe.PID(), actor.WrapEnvelope(&pong{n: i}))
+	}
+
+	envelope := probe.FishForMessage(func(message interface{}) bool {
+		return message.(*pong).n == 3
+	})
+	assert.Equal(t, &pong{n: 3}, envelope.Message)
+	probe.ExpectNoMsg(10 * time.Millisecond)
+}
+
+func TestVirtualClock_ReceiveTimeout(t *testing.T) {
+	tk := New(t)
+	probe := tk.NewProbe()
+	tk.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch ctx.Envelope().Message.(type) {
+		case *actor.Started:
+			ctx.SetReceiveTimeout(time.Minute)
+		case *actor.ReceiveTimeout:
+			ctx.Send(probe.PID(), actor.WrapEnvelope(&tick{}))
+		}
+	}))
+
+	tk.Advance(59 * time.Second)
+	probe.ExpectNoMsg(10 * time.Millisecond)
+	tk.Advance(time.Second)
+	probe.ExpectMsg(&tick{})
+}
+
+func TestVirtualClock_FutureTimeout(t *testing.T) {
+	tk := New(t)
+	silent := tk.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {}))
+
+	future := tk.System.Root.RequestFuture(silent, actor.WrapEnvelope(&ping{}), time.Hour)
+	tk.Advance(time.Hour)
+
+	_, err := future.Result()
+	assert.True(t, errors.Is(err, actor.ErrTimeout))
+}
+
+func TestVirtualClock_SchedulerSendRepeatedly(t *testing.T) {
+	tk := New(t)
+	probe := tk.NewProbe()
+
+	cancel := tk.System.Scheduler.SendRepeatedly(time.Second, time.Second, probe.PID(), actor.WrapEnvelope(&tick{}))
+	tk.Advance(3 * time.Second)
+	for i := 0; i < 3; i++ {
+		probe.ExpectMsg(&tick{})
+	}
+	probe.ExpectNoMsg(10 * time.Millisecond)
+
+	cancel()
+	tk.Advance(time.Minute)
+	probe.ExpectNoMsg(10 * time.Millisecond)
+	assert.Equal(t, 0, tk.Clock.Pending())
+}
+
+func TestVirtualClock_FiresInDeadlineOrder(t *testing.T) {
+	clock := NewVirtualClock(time.Unix(0, 0))
+	var fired []int
+	clock.AfterFunc(2*time.Second, func() { fired = append(fired, 2) })
+	clock.AfterFunc(time.Second, func() {
+		fired = append(fired, 1)
+		clock.AfterFunc(time.Second, func() { fired = append(fired, 3) })
+	})
+	stopped := clock.AfterFunc(time.Second, func() { fired = append(fired, 0) })
+	assert.True(t, stopped.Stop())
+
+	clock.Advance(2 * time.Second)
+	assert.Equal(t, []int{1, 2, 3}, fired)
+	assert.Equal(t, time.Unix(2, 0), clock.Now())
+}
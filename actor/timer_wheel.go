@@ -0,0 +1,133 @@
+package actor
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// timerWheelTick is the resolution of the future timeouts, a future times out at the first tick after its deadline
+	timerWheelTick  = 10 * time.Millisecond
+	timerWheelSlots = 512
+)
+
+// timerWheel times the futures out with a single Config.Clock timer, ticking only while futures are pending.
+// Adding is O(1), a resolved future is removed from its slot so the timer stops at the next tick once none is left.
+type timerWheel struct {
+	clock  Clock
+	tick   time.Duration
+	expire func(id uint32)
+
+	mu      sync.Mutex
+	slots   [][]wheelEntry
+	slotOf  map[uint32]int // slot of every id in the wheel
+	current int
+	pending int
+	timer   Timer
+	armed   bool
+	next    time.Time // when the timer fires next
+}
+
+type wheelEntry struct {
+	id     uint32
+	rounds int
+}
+
+func newTimerWheel(clock Clock, tick time.Duration, expire func(id uint32)) *timerWheel {
+	return &timerWheel{
+		clock:  clock,
+		tick:   tick,
+		expire: expire,
+		slots:  make([][]wheelEntry, timerWheelSlots),
+		slotOf: make(map[uint32]int),
+	}
+}
+
+// add schedules the future id to expire once d elapsed
+func (w *timerWheel) add(id uint32, d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.clock.Now()
+	if !w.armed {
+		w.armed = true
+		w.next = now.Add(w.tick)
+		if w.timer == nil {
+			w.timer = w.clock.AfterFunc(w.tick, w.advance)
+		} else {
+			w.timer.Reset(w.tick)
+		}
+	}
+
+	// the first tick at or after the deadline, counting the next one as 1
+	ticks := 1
+	if late := now.Add(d).Sub(w.next); late > 0 {
+		ticks += int((late + w.tick - 1) / w.tick)
+	}
+	slots := len(w.slots)
+	slot := (w.current + ticks) % slots
+	w.slots[slot] = append(w.slots[slot], wheelEntry{id: id, rounds: (ticks - 1) / slots})
+	w.slotOf[id] = slot
+	w.pending++
+}
+
+// remove unschedules the future id, e.g. once it resolved.
+// The timer is not stopped here, the next tick finds the wheel empty and does not rearm it.
+func (w *timerWheel) remove(id uint32) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	slot, ok := w.slotOf[id]
+	if !ok {
+		return
+	}
+	delete(w.slotOf, id)
+
+	entries := w.slots[slot]
+	for i, e := range entries {
+		if e.id == id {
+			w.slots[slot] = append(entries[:i], entries[i+1:]...)
+			w.pending--
+			return
+		}
+	}
+}
+
+// advance moves the wheel one tick and expires the futures due
+func (w *timerWheel) advance() {
+	w.mu.Lock()
+	w.current = (w.current + 1) % len(w.slots)
+	entries := w.slots[w.current]
+	var expired []uint32
+	kept := entries[:0]
+	for _, e := range entries {
+		if e.rounds > 0 {
+			e.rounds--
+			kept = append(kept, e)
+			continue
+		}
+		expired = append(expired, e.id)
+		delete(w.slotOf, e.id)
+	}
+	w.slots[w.current] = kept
+	w.pending -= len(expired)
+	if w.pending > 0 {
+		w.next = w.clock.Now().Add(w.tick)
+		w.timer.Reset(w.tick)
+	} else {
+		w.armed = false
+	}
+	w.mu.Unlock()
+
+	for _, id := range expired {
+		w.expire(id)
+	}
+}
+
+// len returns the number of futures in the wheel
+func (w *timerWheel) len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.pending
+}
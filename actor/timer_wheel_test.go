@@ -0,0 +1,112 @@
+package actor
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stepClock moves one tick and runs its timer only when fire is called
+type stepClock struct {
+	mu  sync.Mutex
+	now time.Time
+	f   func()
+	on  bool
+}
+
+func (c *stepClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *stepClock) AfterFunc(_ time.Duration, f func()) Timer {
+	c.mu.Lock()
+	c.f, c.on = f, true
+	c.mu.Unlock()
+	return c
+}
+
+func (c *stepClock) Stop() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	on := c.on
+	c.on = false
+	return on
+}
+
+func (c *stepClock) Reset(time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	on := c.on
+	c.on = true
+	return on
+}
+
+func (c *stepClock) fire(n int) {
+	for i := 0; i < n; i++ {
+		c.mu.Lock()
+		f, on := c.f, c.on
+		c.on = false
+		c.now = c.now.Add(10 * time.Millisecond)
+		c.mu.Unlock()
+		if on {
+			f()
+		}
+	}
+}
+
+func TestTimerWheel(t *testing.T) {
+	clock := &stepClock{now: time.Unix(0, 0)}
+	var expired []uint32
+	wheel := newTimerWheel(clock, 10*time.Millisecond, func(id uint32) {
+		expired = append(expired, id)
+	})
+
+	wheel.add(1, 25*time.Millisecond)
+	// a full turn of the wheel and then some
+	wheel.add(2, (timerWheelSlots+5)*10*time.Millisecond)
+	assert.Equal(t, 2, wheel.len())
+
+	clock.fire(2)
+	assert.Empty(t, expired, "expired early")
+	clock.fire(1)
+	assert.Equal(t, []uint32{1}, expired)
+
+	clock.fire(timerWheelSlots + 1)
+	assert.Equal(t, []uint32{1}, expired, "expired after a single turn")
+	clock.fire(1)
+	assert.Equal(t, []uint32{1, 2}, expired)
+
+	assert.Equal(t, 0, wheel.len())
+	assert.False(t, clock.on, "the wheel should stop ticking once empty")
+
+	wheel.add(3, 0)
+	assert.True(t, clock.on)
+	clock.fire(1)
+	assert.Equal(t, []uint32{1, 2, 3}, expired)
+}
+
+func TestTimerWheel_Remove(t *testing.T) {
+	clock := &stepClock{now: time.Unix(0, 0)}
+	var expired []uint32
+	wheel := newTimerWheel(clock, 10*time.Millisecond, func(id uint32) {
+		expired = append(expired, id)
+	})
+
+	wheel.add(1, 25*time.Millisecond)
+	wheel.add(2, 25*time.Millisecond)
+	wheel.remove(1)
+	wheel.remove(4)
+	assert.Equal(t, 1, wheel.len())
+
+	wheel.remove(2)
+	assert.Equal(t, 0, wheel.len())
+	clock.fire(1)
+	assert.False(t, clock.on, "the wheel should stop ticking once its futures resolved")
+
+	clock.fire(3)
+	assert.Empty(t, expired)
+}
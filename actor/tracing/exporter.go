@@ -0,0 +1,59 @@
+package tracing
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// Exporter records the finished spans. Export is called concurrently by the actors and should not block.
+type Exporter interface {
+	Export(span *Span)
+	Close() error
+}
+
+// FileExporter appends the spans to a file, one JSON object per line.
+type FileExporter struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+	err     error
+}
+
+var _ Exporter = &FileExporter{}
+
+// NewFileExporter opens path for appending, it is created if needed
+func NewFileExporter(path string) (*FileExporter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileExporter{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+// Export writes span, a write error is kept and returned by Close
+func (e *FileExporter) Export(span *Span) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.err != nil {
+		return
+	}
+	e.err = e.encoder.Encode(span)
+}
+
+// Close closes the file and returns the first error met
+func (e *FileExporter) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.file.Close(); e.err == nil {
+		e.err = err
+	}
+
+	return e.err
+}
@@ -0,0 +1,58 @@
+package tracing
+
+import "github.com/colin1989/battery/actor"
+
+const (
+	// TraceIDHeader identifies every message caused by one request, e.g. a client request received by an agent
+	TraceIDHeader = "trace-id"
+	// SpanIDHeader is the span of the sender, the span of the receiver is its child
+	SpanIDHeader = "span-id"
+	// CorrelationIDHeader is set by the application to group traces, it is propagated but never generated
+	CorrelationIDHeader = "correlation-id"
+)
+
+// PropagatedHeaders are the headers copied by Propagate when no key is given
+var PropagatedHeaders = []string{TraceIDHeader, SpanIDHeader, CorrelationIDHeader}
+
+// Propagate copies the headers keys of the message being handled into the messages sent and requested meanwhile.
+// A header already set on the outgoing envelope is kept. With no keys, PropagatedHeaders are copied.
+func Propagate(keys ...string) actor.SenderMiddleware {
+	if len(keys) == 0 {
+		keys = PropagatedHeaders
+	}
+
+	return func(next actor.SenderFunc) actor.SenderFunc {
+		return func(c actor.SenderContext, target *actor.PID, envelope *actor.MessageEnvelope) {
+			next(c, target, propagate(c.Envelope(), envelope, keys))
+		}
+	}
+}
+
+// propagate returns envelope with the missing keys copied from current,
+// the envelope is copied first since it may be sent to other actors too.
+func propagate(current, envelope *actor.MessageEnvelope, keys []string) *actor.MessageEnvelope {
+	if current == nil || current.Header == nil {
+		return envelope
+	}
+
+	var header map[string]string
+	for _, key := range keys {
+		value := current.GetHeader(key)
+		if value == "" || envelope.GetHeader(key) != "" {
+			continue
+		}
+		if header == nil {
+			header = envelope.Header.ToMap()
+		}
+		header[key] = value
+	}
+	if header == nil {
+		return envelope
+	}
+
+	return &actor.MessageEnvelope{
+		Header:  header,
+		Message: envelope.Message,
+		Sender:  envelope.Sender,
+	}
+}
@@ -0,0 +1,41 @@
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// Span is the handling of one message by one actor
+type Span struct {
+	TraceID  string        `json:"traceId"`
+	SpanID   string        `json:"spanId"`
+	ParentID string        `json:"parentId,omitempty"`
+	Name     string        `json:"name"`
+	Actor    string        `json:"actor,omitempty"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+
+	tracer *Tracer
+}
+
+// Finish ends the span and exports it, err is the failure of the handling if any.
+func (s *Span) Finish(err error) {
+	s.Duration = time.Since(s.Start)
+	if err != nil {
+		s.Error = err.Error()
+	}
+
+	if s.tracer != nil && s.tracer.exporter != nil {
+		s.tracer.exporter.Export(s)
+	}
+}
+
+// newID returns n random bytes in hex, trace ids use 16 bytes and span ids 8 like W3C trace context
+func newID(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
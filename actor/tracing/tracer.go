@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/colin1989/battery/actor"
+)
+
+// Tracer records the spans of the traced messages to an exporter.
+// A nil *Tracer still starts traces and propagates them, it just records nothing.
+type Tracer struct {
+	exporter Exporter
+}
+
+// NewTracer creates a tracer exporting its spans to exporter
+func NewTracer(exporter Exporter) *Tracer {
+	return &Tracer{exporter: exporter}
+}
+
+// Start begins a span for envelope, continuing its trace or starting a new one,
+// and sets the headers of envelope so the messages it causes are children of the span.
+func (t *Tracer) Start(name string, envelope *actor.MessageEnvelope) *Span {
+	span := &Span{
+		TraceID:  envelope.GetHeader(TraceIDHeader),
+		SpanID:   newID(8),
+		ParentID: envelope.GetHeader(SpanIDHeader),
+		Name:     name,
+		Start:    time.Now(),
+		tracer:   t,
+	}
+	if span.TraceID == "" {
+		span.TraceID = newID(16)
+		envelope.SetHeader(TraceIDHeader, span.TraceID)
+	}
+	envelope.SetHeader(SpanIDHeader, span.SpanID)
+
+	return span
+}
+
+// ReceiverMiddleware records a span for every message carrying a trace id, a panic fails the span.
+func (t *Tracer) ReceiverMiddleware(next actor.ReceiverFunc) actor.ReceiverFunc {
+	return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+		if envelope.GetHeader(TraceIDHeader) == "" {
+			next(c, envelope)
+			return
+		}
+
+		// the envelope may have been sent to other actors, the span headers are set on a copy
+		traced := &actor.MessageEnvelope{
+			Header:  envelope.Header.ToMap(),
+			Message: envelope.Message,
+			Sender:  envelope.Sender,
+		}
+		span := t.Start(fmt.Sprintf("%T", envelope.Message), traced)
+		span.Actor = c.Self().String()
+
+		defer func() {
+			if r := recover(); r != nil {
+				span.Finish(fmt.Errorf("panic: %v", r))
+				panic(r)
+			}
+			span.Finish(nil)
+		}()
+		next(c, traced)
+	}
+}
+
+// WithTracer records the spans of the actor with tracer and propagates its trace to the messages it sends.
+func WithTracer(tracer *Tracer) actor.PropsOption {
+	return func(props *actor.Props) {
+		props.Configure(
+			actor.WithReceiverMiddleware(tracer.ReceiverMiddleware),
+			actor.WithSenderMiddleware(Propagate()),
+		)
+	}
+}
@@ -0,0 +1,130 @@
+package tracing
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/colin1989/battery/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryExporter struct {
+	mu    sync.Mutex
+	spans []*Span
+}
+
+func (e *memoryExporter) Export(span *Span) {
+	e.mu.Lock()
+	e.spans = append(e.spans, span)
+	e.mu.Unlock()
+}
+
+func (e *memoryExporter) Close() error { return nil }
+
+func (e *memoryExporter) byName() map[string]*Span {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	spans := make(map[string]*Span, len(e.spans))
+	for _, span := range e.spans {
+		spans[span.Name] = span
+	}
+	return spans
+}
+
+type request struct{}
+
+type subRequest struct{}
+
+type response struct{}
+
+func TestTracer_FollowsRequestAcrossActors(t *testing.T) {
+	system := actor.NewActorSystem()
+	exporter := &memoryExporter{}
+	tracer := NewTracer(exporter)
+
+	worker := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Envelope().Message.(*subRequest); ok {
+			ctx.Respond(actor.WrapEnvelope(&response{}))
+		}
+	}, WithTracer(tracer)))
+	service := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Envelope().Message.(*request); ok {
+			res, err := ctx.Request(worker, actor.WrapEnvelope(&subRequest{}))
+			assert.NoError(t, err)
+			ctx.Respond(res)
+		}
+	}, WithTracer(tracer)))
+
+	envelope := actor.WrapEnvelope(&request{})
+	root := tracer.Start("agent", envelope)
+	res, err := system.Root.RequestFuture(service, envelope, time.Second).Result()
+	root.Finish(nil)
+	require.NoError(t, err)
+	assert.Equal(t, root.TraceID, res.GetHeader(TraceIDHeader), "the response carries the trace")
+
+	assert.Eventually(t, func() bool { return len(exporter.byName()) == 3 }, time.Second, time.Millisecond)
+	spans := exporter.byName()
+	serviceSpan, workerSpan := spans["*tracing.request"], spans["*tracing.subRequest"]
+	require.NotNil(t, serviceSpan)
+	require.NotNil(t, workerSpan)
+
+	assert.Len(t, root.TraceID, 32)
+	assert.Empty(t, root.ParentID)
+	assert.Equal(t, root.TraceID, serviceSpan.TraceID)
+	assert.Equal(t, root.SpanID, serviceSpan.ParentID)
+	assert.Equal(t, service.String(), serviceSpan.Actor)
+	assert.Equal(t, root.TraceID, workerSpan.TraceID)
+	assert.Equal(t, serviceSpan.SpanID, workerSpan.ParentID)
+}
+
+func TestPropagate(t *testing.T) {
+	current := actor.WrapEnvelope(&request{})
+	current.SetHeader(TraceIDHeader, "trace")
+	current.SetHeader(CorrelationIDHeader, "order-1")
+	current.SetHeader("other", "value")
+
+	outgoing := actor.WrapEnvelope(&subRequest{})
+	outgoing.SetHeader(CorrelationIDHeader, "order-2")
+
+	propagated := propagate(current, outgoing, PropagatedHeaders)
+	assert.Equal(t, "trace", propagated.GetHeader(TraceIDHeader))
+	assert.Equal(t, "order-2", propagated.GetHeader(CorrelationIDHeader), "headers already set are kept")
+	assert.Empty(t, propagated.GetHeader("other"))
+	assert.Empty(t, outgoing.GetHeader(TraceIDHeader), "the outgoing envelope is not modified")
+
+	untraced := actor.WrapEnvelope(&subRequest{})
+	assert.Same(t, untraced, propagate(actor.WrapEnvelope(&request{}), untraced, PropagatedHeaders))
+}
+
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.jsonl")
+	exporter, err := NewFileExporter(path)
+	require.NoError(t, err)
+
+	tracer := NewTracer(exporter)
+	for _, name := range []string{"first", "second"} {
+		tracer.Start(name, actor.WrapEnvelope(&request{})).Finish(nil)
+	}
+	require.NoError(t, exporter.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var span Span
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &span))
+		assert.NotEmpty(t, span.TraceID)
+		names = append(names, span.Name)
+	}
+	assert.Equal(t, []string{"first", "second"}, names)
+}
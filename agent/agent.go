@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"errors"
 	"log/slog"
 	"net"
 	"reflect"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/colin1989/battery/actor"
+	"github.com/colin1989/battery/actor/tracing"
 	"github.com/colin1989/battery/blog"
 	"github.com/colin1989/battery/constant"
 	"github.com/colin1989/battery/facade"
@@ -17,9 +19,37 @@ import (
 	"github.com/colin1989/battery/protos"
 )
 
+var (
+	// errAgentClosed fails the spans of the requests left without a response by a closed agent
+	errAgentClosed = errors.New("agent closed before responding")
+	// errErrorResponse fails the span of a request answered with an error response
+	errErrorResponse = errors.New("error response")
+	// errRequestTimeout fails the span of a request left without a response for the request timeout
+	errRequestTimeout = errors.New("no response before the request timeout")
+)
+
+// requestSpan is the span of a client request waiting for its response
+type requestSpan struct {
+	span   *tracing.Span
+	cancel actor.CancelFunc // cancels the expiry
+}
+
+// expireSpan is scheduled by processMessage to fail the span of a request never answered
+type expireSpan struct {
+	mid  uint
+	span *tracing.Span
+}
+
 type pendingWrite struct {
 	data []byte
 	err  error
+	// closeAfter closes the agent once data is written
+	closeAfter bool
+}
+
+// Kick disconnects the client of the agent after sending it a kick packet
+type Kick struct {
+	Reason string
 }
 
 type Agent struct {
@@ -29,15 +59,28 @@ type Agent struct {
 	chSend chan pendingWrite // push message queue
 	chDie  chan struct{}     // wait for close
 
-	app facade.App
+	app    facade.App
+	tracer *tracing.Tracer // nil when tracing is disabled
 
 	encodedData   []byte // session data encoded as a byte array
 	state         int32  // current agent state
 	session       *protos.Session
 	handshakeData *packet.HandshakeData // handshake data received by the client
+
+	spans map[uint]*requestSpan // spans of the client requests waiting for their response, by message id
 }
 
-func NewAgent(conn facade.Connector, app facade.App) actor.Actor {
+// Option configures an Agent
+type Option func(a *Agent)
+
+// WithTracer starts a trace for every client request handled by the agent
+func WithTracer(tracer *tracing.Tracer) Option {
+	return func(a *Agent) {
+		a.tracer = tracer
+	}
+}
+
+func NewAgent(conn facade.Connector, app facade.App, opts ...Option) actor.Actor {
 
 	heartbeatTime := time.Minute
 	isCompressionEnabled := true
@@ -47,13 +90,18 @@ func NewAgent(conn facade.Connector, app facade.App) actor.Actor {
 		herdEncode(heartbeatTime, app.Encoder(), isCompressionEnabled, serializerName)
 	})
 
-	return &Agent{
+	a := &Agent{
 		conn:    conn,
 		chSend:  make(chan pendingWrite),
 		chDie:   make(chan struct{}),
 		app:     app,
 		session: &protos.Session{Data: make(map[string]string)},
+		spans:   make(map[uint]*requestSpan),
+	}
+	for _, opt := range opts {
+		opt(a)
 	}
+	return a
 }
 
 func (a *Agent) Receive(ctx actor.Context) {
@@ -70,9 +118,16 @@ func (a *Agent) Receive(ctx actor.Context) {
 		blog.Debug("actor stopping", slog.String("pid", ctx.Self().String()))
 	case *actor.Stopped:
 		blog.Debug("actor stopped", slog.String("pid", ctx.Self().String()))
+		a.finishSpans(errAgentClosed)
 		a.Close()
 	case message.PendingMessage:
 		sendPacket(a, msg)
+	case *Kick:
+		a.kick(msg.Reason)
+	case *expireSpan:
+		if pending, ok := a.spans[msg.mid]; ok && pending.span == msg.span {
+			a.finishSpan(msg.mid, errRequestTimeout)
+		}
 	//case *actor.ReceiveTimeout:
 	//	ctx.Stop(ctx.Self())
 	case *message.BroadcastMessage:
@@ -97,6 +152,38 @@ func (a *Agent) Receive(ctx actor.Context) {
 	}
 }
 
+// finishSpan ends the span of the request mid, once its response is written to the client
+func (a *Agent) finishSpan(mid uint, err error) {
+	pending, ok := a.spans[mid]
+	if !ok {
+		return
+	}
+	delete(a.spans, mid)
+	pending.cancel()
+	pending.span.Finish(err)
+}
+
+// startSpan keeps the span of the request mid until its response, or fails it after the request timeout
+func (a *Agent) startSpan(mid uint, span *tracing.Span) {
+	// a client reusing an id gave up the previous request
+	a.finishSpan(mid, errRequestTimeout)
+
+	timeout := a.ctx.ActorSystem().Config.DefaultRequestTimeout
+	a.spans[mid] = &requestSpan{
+		span:   span,
+		cancel: a.ctx.Scheduler().SendOnce(timeout, a.pid, actor.WrapEnvelope(&expireSpan{mid: mid, span: span})),
+	}
+}
+
+// finishSpans fails the spans of the requests left without a response
+func (a *Agent) finishSpans(err error) {
+	for mid, pending := range a.spans {
+		delete(a.spans, mid)
+		pending.cancel()
+		pending.span.Finish(err)
+	}
+}
+
 func (a *Agent) PID() string {
 	return a.pid.String()
 }
@@ -127,13 +214,39 @@ func (a *Agent) SetLastAt() {
 }
 
 func (a *Agent) Close() {
-	if atomic.LoadInt32(&a.state) == constant.StatusClosed {
+	// Close is called by the reader, the writer and the actor, only the first one closes
+	if atomic.SwapInt32(&a.state, constant.StatusClosed) == constant.StatusClosed {
 		return
 	}
-	atomic.StoreInt32(&a.state, constant.StatusClosed)
 	close(a.chDie)
 	a.conn.Close()
 	a.ctx.Poison(a.ctx.Self())
+
+	a.ctx.ActorSystem().EventStream.Publish(&SessionClosedEvent{
+		PID:        a.pid,
+		RemoteAddr: a.RemoteAddr(),
+	})
+}
+
+func (a *Agent) kick(reason string) {
+	if a.CheckStatus(constant.StatusClosed) {
+		return
+	}
+
+	blog.Info("kick session", slog.String("pid", a.PID()), slog.String("reason", reason))
+	a.ctx.ActorSystem().EventStream.Publish(&SessionKickedEvent{
+		PID:        a.pid,
+		RemoteAddr: a.RemoteAddr(),
+		Reason:     reason,
+	})
+
+	// the writer may be stuck on a slow client, the actor must not wait for it
+	go func() {
+		select {
+		case a.chSend <- pendingWrite{data: kd, closeAfter: true}:
+		case <-a.chDie:
+		}
+	}()
 }
 
 func (a *Agent) send(data []byte) error {
@@ -169,6 +282,9 @@ func (a *Agent) write() {
 				blog.Error("Failed to write in conn", blog.ErrAttr(err))
 				return
 			}
+			if pWrite.closeAfter {
+				return
+			}
 		case <-a.chDie:
 			return
 		}
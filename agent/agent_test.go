@@ -0,0 +1,202 @@
+package agent
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/colin1989/battery/actor"
+	"github.com/colin1989/battery/facade"
+	"github.com/colin1989/battery/net/codec"
+	"github.com/colin1989/battery/net/message"
+	"github.com/colin1989/battery/net/packet"
+	"github.com/colin1989/battery/serializer/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testApp struct {
+	messageEncoder facade.MessageEncoder
+	decoder        facade.PacketDecoder
+	encoder        facade.PacketEncoder
+	serializer     facade.Serializer
+}
+
+func (t *testApp) MessageEncoder() facade.MessageEncoder {
+	return t.messageEncoder
+}
+
+func (t *testApp) Decoder() facade.PacketDecoder {
+	return t.decoder
+}
+
+func (t *testApp) Encoder() facade.PacketEncoder {
+	return t.encoder
+}
+
+func (t *testApp) Serializer() facade.Serializer {
+	return t.serializer
+}
+
+var tapp = &testApp{
+	messageEncoder: message.NewMessagesEncoder(true),
+	decoder:        codec.NewPomeloPacketDecoder(),
+	encoder:        codec.NewPomeloPacketEncoder(),
+	serializer:     json.NewSerializer(),
+}
+
+// pipeConn is a Connector over one end of a net.Pipe
+type pipeConn struct {
+	net.Conn
+}
+
+func (c *pipeConn) GetNextMessage() ([]byte, error) {
+	header := make([]byte, codec.HeadLength)
+	if _, err := io.ReadFull(c.Conn, header); err != nil {
+		return nil, err
+	}
+	_, size, err := codec.ParseHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(c.Conn, data); err != nil {
+		return nil, err
+	}
+
+	return append(header, data...), nil
+}
+
+// sessionEvents records the session events of an actor system
+type sessionEvents struct {
+	handshaked chan *SessionHandshakedEvent
+	kicked     chan *SessionKickedEvent
+	closed     chan *SessionClosedEvent
+}
+
+func subscribeSessionEvents(system *actor.ActorSystem) *sessionEvents {
+	events := &sessionEvents{
+		handshaked: make(chan *SessionHandshakedEvent, 10),
+		kicked:     make(chan *SessionKickedEvent, 10),
+		closed:     make(chan *SessionClosedEvent, 10),
+	}
+	actor.SubscribeTo(system.EventStream, func(evt *SessionHandshakedEvent) { events.handshaked <- evt })
+	actor.SubscribeTo(system.EventStream, func(evt *SessionKickedEvent) { events.kicked <- evt })
+	actor.SubscribeTo(system.EventStream, func(evt *SessionClosedEvent) { events.closed <- evt })
+
+	return events
+}
+
+// spawnAgent spawns an agent serving the server end of a pipe, the packets it writes are read from the client end
+func spawnAgent(t *testing.T, system *actor.ActorSystem) (*actor.PID, net.Conn, chan *packet.Packet) {
+	server, client := net.Pipe()
+	pid := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor {
+		return NewAgent(&pipeConn{Conn: server}, tapp)
+	}))
+
+	packets := make(chan *packet.Packet, 10)
+	go func() {
+		conn := &pipeConn{Conn: client}
+		for {
+			data, err := conn.GetNextMessage()
+			if err != nil {
+				return
+			}
+			decoded, err := tapp.Decoder().Decode(data)
+			if err != nil {
+				return
+			}
+			for _, p := range decoded {
+				packets <- p
+			}
+		}
+	}()
+
+	return pid, client, packets
+}
+
+func handshake(t *testing.T, client net.Conn, packets chan *packet.Packet) {
+	data, err := tapp.Encoder().Encode(packet.Handshake, []byte(`{"sys":{"platform":"test","libVersion":"1.0.0"}}`))
+	require.NoError(t, err)
+	_, err = client.Write(data)
+	require.NoError(t, err)
+	assert.EqualValues(t, packet.Handshake, waitFor(t, packets).Type)
+}
+
+func waitFor[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(time.Second):
+		t.Fatal("timed out")
+		panic("unreachable")
+	}
+}
+
+func TestAgent_SessionHandshaked(t *testing.T) {
+	system := actor.NewActorSystem()
+	events := subscribeSessionEvents(system)
+	pid, client, packets := spawnAgent(t, system)
+	defer client.Close()
+
+	handshake(t, client, packets)
+
+	evt := waitFor(t, events.handshaked)
+	assert.True(t, evt.PID.Equal(pid))
+	assert.Equal(t, "test", evt.HandshakeData.Sys.Platform)
+}
+
+func TestAgent_SessionClosed(t *testing.T) {
+	system := actor.NewActorSystem()
+	events := subscribeSessionEvents(system)
+	pid, client, packets := spawnAgent(t, system)
+	handshake(t, client, packets)
+
+	require.NoError(t, client.Close())
+
+	evt := waitFor(t, events.closed)
+	assert.True(t, evt.PID.Equal(pid))
+	select {
+	case <-events.closed:
+		t.Fatal("SessionClosedEvent should be published once")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Empty(t, events.kicked)
+}
+
+func TestAgent_SessionKicked(t *testing.T) {
+	system := actor.NewActorSystem()
+	events := subscribeSessionEvents(system)
+	pid, client, packets := spawnAgent(t, system)
+	defer client.Close()
+	handshake(t, client, packets)
+
+	system.Root.Send(pid, actor.WrapEnvelope(&Kick{Reason: "maintenance"}))
+
+	kicked := waitFor(t, events.kicked)
+	assert.True(t, kicked.PID.Equal(pid))
+	assert.Equal(t, "maintenance", kicked.Reason)
+	assert.EqualValues(t, packet.Kick, waitFor(t, packets).Type)
+	assert.True(t, waitFor(t, events.closed).PID.Equal(pid))
+}
+
+func TestAgent_KickDoesNotWaitForTheClient(t *testing.T) {
+	system := actor.NewActorSystem()
+	events := subscribeSessionEvents(system)
+	server, client := net.Pipe()
+	defer client.Close()
+	// nobody reads the client end, so the writer of the agent blocks on its first packet
+	pid := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor {
+		return NewAgent(&pipeConn{Conn: server}, tapp)
+	}))
+	system.Root.Send(pid, actor.WrapEnvelope(&message.BroadcastMessage{P: []byte("blocked")}))
+
+	system.Root.Send(pid, actor.WrapEnvelope(&Kick{Reason: "slow"}))
+	waitFor(t, events.kicked)
+
+	// the agent is still able to handle its messages
+	assert.NoError(t, system.Root.StopFuture(pid).Wait())
+	waitFor(t, events.closed)
+}
@@ -0,0 +1,40 @@
+package agent
+
+import (
+	"net"
+
+	"github.com/colin1989/battery/actor"
+	"github.com/colin1989/battery/net/packet"
+)
+
+// SessionHandshakedEvent is published on the EventStream when a client completed the handshake
+type SessionHandshakedEvent struct {
+	PID           *actor.PID
+	RemoteAddr    net.Addr
+	HandshakeData *packet.HandshakeData
+}
+
+var _ actor.EventMessage = &SessionHandshakedEvent{}
+
+func (*SessionHandshakedEvent) EventMessage() {}
+
+// SessionKickedEvent is published on the EventStream when a session is kicked, it is followed by a SessionClosedEvent.
+type SessionKickedEvent struct {
+	PID        *actor.PID
+	RemoteAddr net.Addr
+	Reason     string
+}
+
+var _ actor.EventMessage = &SessionKickedEvent{}
+
+func (*SessionKickedEvent) EventMessage() {}
+
+// SessionClosedEvent is published on the EventStream once when the connection of a session is closed
+type SessionClosedEvent struct {
+	PID        *actor.PID
+	RemoteAddr net.Addr
+}
+
+var _ actor.EventMessage = &SessionClosedEvent{}
+
+func (*SessionClosedEvent) EventMessage() {}
@@ -17,6 +17,8 @@ var (
 	hrd []byte
 	// herd contains the handshake error response data
 	herd []byte
+	// kd contains the kick packet
+	kd   []byte
 	once sync.Once
 )
 
@@ -44,6 +46,11 @@ func hbdEncode(heartbeatTimeout time.Duration, packetEncoder facade.PacketEncode
 	if err != nil {
 		panic(err)
 	}
+
+	kd, err = packetEncoder.Encode(packet.Kick, nil)
+	if err != nil {
+		panic(err)
+	}
 }
 
 func herdEncode(heartbeatTimeout time.Duration, packetEncoder facade.PacketEncoder, dataCompression bool, serializerName string) {
@@ -57,6 +57,12 @@ func processPacket(a *Agent, p *packet.Packet) error {
 
 		blog.Debug("Successfully saved handshake data")
 
+		a.ctx.ActorSystem().EventStream.Publish(&SessionHandshakedEvent{
+			PID:           a.pid,
+			RemoteAddr:    a.RemoteAddr(),
+			HandshakeData: handshakeData,
+		})
+
 	case packet.HandshakeAck:
 		a.SetStatus(constant.StatusWorking)
 		blog.Debug("Receive handshake ACK",
@@ -90,6 +96,28 @@ func processMessage(a *Agent, msg *message.Message) {
 
 	// TODO 判断是否为 remote
 	system := a.ctx.ActorSystem()
-	pid := system.NewLocalPID(msg.Route.Service)
-	system.Root.Send(pid, actor.WrapEnvelopWithSender(msg, a.pid))
+	pid, err := system.Lookup(msg.Route.Service)
+	if err != nil {
+		blog.Warn("route message to unknown service", slog.String("pid", a.PID()),
+			slog.String("service", msg.Route.Service), slog.String("method", msg.Route.Method), blog.ErrAttr(err))
+		return
+	}
+	envelope := actor.WrapEnvelopWithSender(msg, a.pid)
+	if a.tracer == nil {
+		system.Root.Send(pid, envelope)
+		return
+	}
+
+	// every client request starts a trace, followed by the services through the message headers
+	span := a.tracer.Start("agent "+msg.Route.String(), envelope)
+	span.Actor = a.PID()
+	system.Root.Send(pid, envelope)
+
+	// the span of a request lasts until its response is written to the client, see sendPacket, or until the
+	// request timeout. A notify gets no response, its span is finished at once and only marks the root of the trace.
+	if msg.Type == message.Request {
+		a.startSpan(msg.ID, span)
+		return
+	}
+	span.Finish(nil)
 }
@@ -10,6 +10,16 @@ import (
 )
 
 func sendPacket(a *Agent, pendingMessage message.PendingMessage) {
+	var err error
+	if pendingMessage.Typ == message.Response {
+		defer func() {
+			if err == nil && pendingMessage.Err {
+				err = errErrorResponse
+			}
+			a.finishSpan(pendingMessage.Mid, err)
+		}()
+	}
+
 	payload, _ := util.SerializeOrRaw(a.app.Serializer(), pendingMessage.Payload)
 	// construct message and encode
 	m := &message.Message{
@@ -32,7 +42,7 @@ func sendPacket(a *Agent, pendingMessage message.PendingMessage) {
 			blog.ErrAttr(err))
 		return
 	}
-	if err := a.send(p); err != nil {
+	if err = a.send(p); err != nil {
 		blog.Error("actor send client", slog.String("pid", a.PID()),
 			blog.ErrAttr(err))
 	}
@@ -1,15 +1,20 @@
 package battery
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
+	"strings"
 	"sync/atomic"
 	"syscall"
+	"time"
 
 	"github.com/colin1989/battery/actor"
+	"github.com/colin1989/battery/actor/tracing"
 	"github.com/colin1989/battery/blog"
+	"github.com/colin1989/battery/constant"
 	"github.com/colin1989/battery/facade"
 	"github.com/colin1989/battery/service"
 )
@@ -39,9 +44,11 @@ type Application struct {
 	encoder        facade.PacketEncoder
 	serializer     facade.Serializer
 
-	system   *actor.ActorSystem
-	services []facade.Service
-	actors   actor.PIDSet // actor was spawn by root context
+	system          *actor.ActorSystem
+	services        []facade.Service
+	actors          actor.PIDSet // actor was spawn by root context
+	shutdownTimeout time.Duration
+	tracer          *tracing.Tracer
 }
 
 func (app *Application) Register(s facade.Service) {
@@ -80,6 +87,9 @@ func (app *Application) addService(s facade.Service) {
 	props := actor.PropsFromProducer(func() actor.Actor {
 		return as
 	}).Configure(actor.WithMailbox(actor.UnboundedLockfree()))
+	if app.tracer != nil {
+		props.Configure(tracing.WithTracer(app.tracer))
+	}
 	pid, err := app.system.Root.SpawnNamed(props, s.Name())
 	if err != nil {
 		blog.Fatal("new service", slog.Any("service", s.Name()), blog.ErrAttr(err))
@@ -107,6 +117,11 @@ func (app *Application) Start() {
 		app.addService(s)
 	}
 
+	if len(inspectSignals) > 0 {
+		stopInspect := app.system.InspectOnSignal(os.Stderr, inspectSignals...)
+		defer stopInspect()
+	}
+
 	sg := make(chan os.Signal, 1)
 	signal.Notify(sg, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGKILL)
 
@@ -128,9 +143,45 @@ func (app *Application) Start() {
 
 func (app *Application) shutdownActorSystem() {
 	blog.Info("actor system is stopping ...")
-	app.actors.ForEach(func(_ int, pid *actor.PID) {
-		app.system.Root.Poison(pid)
-	})
-	app.system.Shutdown()
+	ctx, cancel := context.WithTimeout(context.Background(), app.shutdownTimeout)
+	defer cancel()
+
+	if err := app.system.ShutdownWithTimeout(ctx, app.shutdownStages()...); err != nil {
+		blog.Error("actor system shutdown timeout", blog.ErrAttr(err))
+	}
 	blog.Info("actor system is stopped")
 }
+
+// shutdownStages stops the acceptors first so no new agent is spawned, then the agents, then the services.
+// The gate itself is stopped with the remaining actors.
+func (app *Application) shutdownStages() []actor.ShutdownStage {
+	gatePrefix := constant.Gate + "/"
+	childrenOfGate := func(prefixes ...string) func() []*actor.PID {
+		return func() []*actor.PID {
+			var pids []*actor.PID
+			for _, pid := range app.system.ProcessRegistry.PIDs() {
+				for _, prefix := range prefixes {
+					if strings.HasPrefix(pid.ID, gatePrefix+prefix) {
+						pids = append(pids, pid)
+						break
+					}
+				}
+			}
+			return pids
+		}
+	}
+
+	return []actor.ShutdownStage{
+		{Name: "gate", PIDs: childrenOfGate(constant.TCPAcceptor, constant.WSAcceptor)},
+		{Name: "agents", PIDs: childrenOfGate(constant.AgentPrefix)},
+		{Name: "services", PIDs: func() []*actor.PID {
+			pids := make([]*actor.PID, 0, app.actors.Len())
+			app.actors.ForEach(func(_ int, pid *actor.PID) {
+				if pid.ID != constant.Gate {
+					pids = append(pids, pid)
+				}
+			})
+			return pids
+		}},
+	}
+}
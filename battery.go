@@ -2,6 +2,7 @@ package battery
 
 import (
 	"log/slog"
+	"time"
 
 	"github.com/colin1989/battery/errors"
 
@@ -22,6 +23,8 @@ func NewApp(opts ...Option) *Application {
 		serverMode: Cluster,
 		system:     system,
 
+		shutdownTimeout: 30 * time.Second,
+
 		messageEncoder: message.NewMessagesEncoder(true),
 		decoder:        codec.NewPomeloPacketDecoder(),
 		encoder:        codec.NewPomeloPacketEncoder(),
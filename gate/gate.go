@@ -5,6 +5,7 @@ import (
 	"reflect"
 
 	"github.com/colin1989/battery/actor"
+	"github.com/colin1989/battery/actor/tracing"
 	"github.com/colin1989/battery/agent"
 	"github.com/colin1989/battery/blog"
 	"github.com/colin1989/battery/constant"
@@ -17,14 +18,28 @@ type Gate struct {
 	pid       *actor.PID
 	acceptors []facade.Acceptors
 
-	app facade.App
+	app    facade.App
+	tracer *tracing.Tracer
 }
 
-func NewGate(acceptors []facade.Acceptors, app facade.App) *Gate {
+// Option configures a Gate
+type Option func(gs *Gate)
+
+// WithTracer traces the client requests of the agents spawned by the gate
+func WithTracer(tracer *tracing.Tracer) Option {
+	return func(gs *Gate) {
+		gs.tracer = tracer
+	}
+}
+
+func NewGate(acceptors []facade.Acceptors, app facade.App, opts ...Option) *Gate {
 	ga := &Gate{
 		acceptors: acceptors,
 		app:       app,
 	}
+	for _, opt := range opts {
+		opt(ga)
+	}
 	return ga
 }
 
@@ -78,8 +93,11 @@ func (gs *Gate) Receive(ctx actor.Context) {
 	case facade.Connector:
 		conn := msg
 		props := actor.PropsFromProducer(func() actor.Actor {
-			return agent.NewAgent(conn, gs.app)
+			return agent.NewAgent(conn, gs.app, agent.WithTracer(gs.tracer))
 		})
+		if gs.tracer != nil {
+			props.Configure(tracing.WithTracer(gs.tracer))
+		}
 		pid := ctx.SpawnPrefix(props, constant.AgentPrefix)
 		_ = pid
 	default:
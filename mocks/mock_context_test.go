@@ -65,7 +65,12 @@ func (m *mockContext) Respond(envelope *actor.MessageEnvelope) {
 	m.Called(envelope)
 }
 
-func (m *mockContext) Stash() {
+func (m *mockContext) Stash() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *mockContext) UnstashAll() {
 	m.Called()
 }
 
@@ -73,10 +78,31 @@ func (m *mockContext) Watch(pid *actor.PID) {
 	m.Called(pid)
 }
 
+func (m *mockContext) Scheduler() *actor.Scheduler {
+	args := m.Called()
+	return args.Get(0).(*actor.Scheduler)
+}
+
+func (m *mockContext) Become(receive actor.ReceiveFunc) {
+	m.Called(receive)
+}
+
+func (m *mockContext) BecomeStacked(receive actor.ReceiveFunc) {
+	m.Called(receive)
+}
+
+func (m *mockContext) UnbecomeStacked() {
+	m.Called()
+}
+
 func (m *mockContext) Unwatch(pid *actor.PID) {
 	m.Called(pid)
 }
 
+func (m *mockContext) ReenterAfter(future *actor.Future, continuation func(res *actor.MessageEnvelope, err error), opts ...actor.ReenterOption) {
+	m.Called(future, continuation)
+}
+
 func (m *mockContext) Envelope() *actor.MessageEnvelope {
 	args := m.Called()
 	return args.Get(0).(*actor.MessageEnvelope)
@@ -98,11 +124,16 @@ func (m *mockContext) Send(pid *actor.PID, envelope *actor.MessageEnvelope) {
 	p.SendUserMessage(pid, envelope)
 }
 
-func (m *mockContext) Request(pid *actor.PID, envelop *actor.MessageEnvelope) (*actor.MessageEnvelope, error) {
+func (m *mockContext) Request(pid *actor.PID, envelop *actor.MessageEnvelope, _ ...actor.RequestOption) (*actor.MessageEnvelope, error) {
 	args := m.Called(pid, envelop)
 	return args.Get(0).(*actor.MessageEnvelope), args.Get(0).(error)
 }
 
+func (m *mockContext) RequestFuture(pid *actor.PID, envelop *actor.MessageEnvelope, timeout time.Duration) *actor.Future {
+	args := m.Called(pid, envelop, timeout)
+	return args.Get(0).(*actor.Future)
+}
+
 func (m *mockContext) Receive(envelope *actor.MessageEnvelope) {
 	m.Called(envelope)
 }
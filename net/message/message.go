@@ -98,6 +98,11 @@ func (m *Message) String() string {
 		len(m.Data))
 }
 
+// RouteName returns the route of the message, it is logged when the message becomes a dead letter
+func (m *Message) RouteName() string {
+	return m.Route.String()
+}
+
 func routable(t Type) bool {
 	return t == Request || t == Notify || t == Push
 }
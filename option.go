@@ -1,7 +1,10 @@
 package battery
 
 import (
+	"time"
+
 	"github.com/colin1989/battery/actor"
+	"github.com/colin1989/battery/actor/tracing"
 	"github.com/colin1989/battery/constant"
 	"github.com/colin1989/battery/facade"
 	"github.com/colin1989/battery/gate"
@@ -24,11 +27,28 @@ func WithClusterMode() Option {
 	}
 }
 
+// WithShutdownTimeout bounds how long the actors get to stop when the application shuts down
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(app *Application) error {
+		app.shutdownTimeout = timeout
+		return nil
+	}
+}
+
+// WithTracer records the spans of the client requests handled by the agents and the services.
+// It must be given before WithGate, which spawns the gate at once.
+func WithTracer(tracer *tracing.Tracer) Option {
+	return func(app *Application) error {
+		app.tracer = tracer
+		return nil
+	}
+}
+
 func WithGate(acceptors []facade.Acceptors) Option {
 	return func(app *Application) error {
 		producer := actor.PropsFromProducer(
 			func() actor.Actor {
-				return gate.NewGate(acceptors, app)
+				return gate.NewGate(acceptors, app, gate.WithTracer(app.tracer))
 			})
 		pid, err := app.system.Root.SpawnNamed(producer, constant.Gate)
 		if err != nil {
@@ -46,3 +46,81 @@ func TestBroadcastRouterThreadSafe(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestBroadcastRouter_MessageBatch(t *testing.T) {
+	events := make(chan int, 20)
+	props := actor.PropsFromFunc(func(c actor.Context) {
+		if n, ok := c.Envelope().Message.(int); ok {
+			events <- n
+		}
+	})
+
+	routees := make([]*actor.PID, 2)
+	for i := range routees {
+		routees[i] = system.Root.Spawn(props)
+		defer system.Root.Stop(routees[i])
+	}
+	grp := system.Root.Spawn(NewBroadcastGroup(routees...))
+	defer system.Root.Stop(grp)
+
+	system.Root.Send(grp, actor.WrapBatch(actor.WrapEnvelope(1), actor.WrapEnvelope(2)))
+
+	sum := 0
+	for i := 0; i < 4; i++ {
+		select {
+		case n := <-events:
+			sum += n
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for the batch")
+		}
+	}
+	if sum != 6 {
+		t.Fatalf("every routee should receive the whole batch, got sum %d", sum)
+	}
+}
+
+func TestRoundRobinRouter_MessageBatchIsRoutedPerEnvelope(t *testing.T) {
+	events := make(chan *actor.PID, 20)
+	props := actor.PropsFromFunc(func(c actor.Context) {
+		if _, ok := c.Envelope().Message.(int); ok {
+			events <- c.Self()
+		}
+	})
+
+	a, b := system.Root.Spawn(props), system.Root.Spawn(props)
+	defer system.Root.Stop(a)
+	defer system.Root.Stop(b)
+	grp := system.Root.Spawn(NewRoundRobinGroup(a, b))
+	defer system.Root.Stop(grp)
+
+	system.Root.Send(grp, actor.WrapBatch(actor.WrapEnvelope(1), actor.WrapEnvelope(2)))
+
+	seen := actor.NewPIDSet()
+	for i := 0; i < 2; i++ {
+		select {
+		case pid := <-events:
+			seen.Add(pid)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for the batch")
+		}
+	}
+	if seen.Len() != 2 {
+		t.Fatalf("the envelopes should be spread over both routees, got %d", seen.Len())
+	}
+}
+
+func TestLookupRouter(t *testing.T) {
+	pid, err := system.Root.SpawnNamed(NewBroadcastGroup(), "lookup_router")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer system.Root.Stop(pid)
+
+	found, err := system.Lookup("lookup_router")
+	if err != nil {
+		t.Fatalf("lookup router: %v", err)
+	}
+	if !found.Equal(pid) {
+		t.Fatalf("lookup router: got %s, want %s", found, pid)
+	}
+}
@@ -29,6 +29,15 @@ func (ref *routerProcess) SendUserMessage(pid *actor.PID, envelope *actor.Messag
 		ref.Poison(pid)
 		return
 	}
+	// a broadcast forwards the batch as a single message to every routee, other routers route every envelope
+	if batch, ok := msg.(*actor.MessageBatch); ok {
+		if _, broadcast := ref.state.(*broadcastRouterState); !broadcast {
+			for _, item := range batch.Envelopes {
+				ref.SendUserMessage(pid, item)
+			}
+			return
+		}
+	}
 	if _, ok := msg.(ManagementMessage); !ok {
 		ref.state.RouteMessage(envelope)
 	} else {
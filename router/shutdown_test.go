@@ -0,0 +1,26 @@
+package router
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/colin1989/battery/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShutdownWithTimeoutStopsRootRouters(t *testing.T) {
+	as := actor.NewActorSystem()
+	routee := as.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {}))
+	_, err := as.Root.SpawnNamed(NewBroadcastGroup(routee), "bcast")
+	require.NoError(t, err)
+	as.Root.Spawn(NewBroadcastPool(3, actor.WithFunc(func(ctx actor.Context) {})))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	start := time.Now()
+	require.NoError(t, as.ShutdownWithTimeout(ctx))
+	assert.Less(t, time.Since(start), time.Second)
+	assert.True(t, as.IsStopped())
+}
@@ -0,0 +1,216 @@
+package serialize
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/colin1989/battery/serializer/json"
+	"github.com/colin1989/battery/serializer/protobuf"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+var (
+	// ErrUnknownSerializer is returned when data references a serializer that is not registered.
+	ErrUnknownSerializer = errors.New("serializer: unknown serializer")
+
+	// ErrUnknownType is returned when no serializer knows how to encode or decode a message type.
+	ErrUnknownType = errors.New("serializer: unknown message type")
+)
+
+// MessageSerializer converts messages of any type to bytes, with the type name needed to decode them.
+// It is shared by the remote transport and the persistence journal.
+type MessageSerializer interface {
+	// CanSerialize reports whether msg can be encoded by this serializer
+	CanSerialize(msg interface{}) bool
+	Serialize(msg interface{}) ([]byte, error)
+	Deserialize(typeName string, data []byte) (interface{}, error)
+	GetTypeName(msg interface{}) (string, error)
+}
+
+var (
+	serializersMu sync.RWMutex
+	serializers   = []MessageSerializer{
+		newProtoSerializer(),
+		newJsonSerializer(),
+	}
+)
+
+// RegisterMessageSerializer appends a serializer and returns its id.
+// Every process must register the same serializers in the same order.
+func RegisterMessageSerializer(serializer MessageSerializer) byte {
+	serializersMu.Lock()
+	defer serializersMu.Unlock()
+
+	serializers = append(serializers, serializer)
+	return byte(len(serializers) - 1)
+}
+
+// RegisterType registers a non-protobuf message type so it can be encoded as JSON.
+// Every process must register the type before messages of that type are exchanged or replayed.
+func RegisterType(prototypes ...interface{}) {
+	for _, prototype := range prototypes {
+		jsonTypes.register(prototype)
+	}
+}
+
+// Serialize encodes msg with the first serializer able to, and returns the type name and serializer id
+// needed to decode it with Deserialize.
+func Serialize(msg interface{}) ([]byte, string, byte, error) {
+	serializersMu.RLock()
+	defer serializersMu.RUnlock()
+
+	for id, s := range serializers {
+		if !s.CanSerialize(msg) {
+			continue
+		}
+
+		typeName, err := s.GetTypeName(msg)
+		if err != nil {
+			return nil, "", 0, err
+		}
+
+		data, err := s.Serialize(msg)
+		if err != nil {
+			return nil, "", 0, err
+		}
+
+		return data, typeName, byte(id), nil
+	}
+
+	return nil, "", 0, fmt.Errorf("%w: %T", ErrUnknownType, msg)
+}
+
+// Deserialize decodes data produced by Serialize
+func Deserialize(data []byte, typeName string, serializerID byte) (interface{}, error) {
+	serializersMu.RLock()
+	if int(serializerID) >= len(serializers) {
+		serializersMu.RUnlock()
+		return nil, ErrUnknownSerializer
+	}
+	s := serializers[serializerID]
+	serializersMu.RUnlock()
+
+	return s.Deserialize(typeName, data)
+}
+
+type protoSerializer struct {
+	*protobuf.Serializer
+}
+
+func newProtoSerializer() MessageSerializer {
+	return &protoSerializer{Serializer: protobuf.NewSerializer()}
+}
+
+func (p *protoSerializer) CanSerialize(msg interface{}) bool {
+	_, ok := msg.(proto.Message)
+	return ok
+}
+
+func (p *protoSerializer) Serialize(msg interface{}) ([]byte, error) {
+	return p.Marshal(msg)
+}
+
+func (p *protoSerializer) Deserialize(typeName string, data []byte) (interface{}, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(typeName))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, typeName)
+	}
+
+	msg := mt.New().Interface()
+	if err := p.Unmarshal(data, msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+func (p *protoSerializer) GetTypeName(msg interface{}) (string, error) {
+	pb, ok := msg.(proto.Message)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrUnknownType, msg)
+	}
+
+	return string(proto.MessageName(pb)), nil
+}
+
+type jsonSerializer struct {
+	*json.Serializer
+}
+
+func newJsonSerializer() MessageSerializer {
+	return &jsonSerializer{Serializer: json.NewSerializer()}
+}
+
+func (j *jsonSerializer) CanSerialize(msg interface{}) bool {
+	_, ok := jsonTypes.lookup(typeName(reflect.TypeOf(msg)))
+	return ok
+}
+
+func (j *jsonSerializer) Serialize(msg interface{}) ([]byte, error) {
+	return j.Marshal(msg)
+}
+
+func (j *jsonSerializer) Deserialize(name string, data []byte) (interface{}, error) {
+	t, ok := jsonTypes.lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, name)
+	}
+
+	if t.Kind() == reflect.Ptr {
+		v := reflect.New(t.Elem())
+		if err := j.Unmarshal(data, v.Interface()); err != nil {
+			return nil, err
+		}
+		return v.Interface(), nil
+	}
+
+	v := reflect.New(t)
+	if err := j.Unmarshal(data, v.Interface()); err != nil {
+		return nil, err
+	}
+	return v.Elem().Interface(), nil
+}
+
+func (j *jsonSerializer) GetTypeName(msg interface{}) (string, error) {
+	return typeName(reflect.TypeOf(msg)), nil
+}
+
+// typeName returns a stable name for t, which is the same in every process built from the same source.
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return ""
+	}
+
+	if t.Kind() == reflect.Ptr {
+		return "*" + t.Elem().PkgPath() + "." + t.Elem().Name()
+	}
+
+	return t.PkgPath() + "." + t.Name()
+}
+
+type typeRegistry struct {
+	sync.RWMutex
+	types map[string]reflect.Type
+}
+
+var jsonTypes = &typeRegistry{types: make(map[string]reflect.Type)}
+
+func (r *typeRegistry) register(prototype interface{}) {
+	t := reflect.TypeOf(prototype)
+
+	r.Lock()
+	r.types[typeName(t)] = t
+	r.Unlock()
+}
+
+func (r *typeRegistry) lookup(name string) (reflect.Type, bool) {
+	r.RLock()
+	t, ok := r.types[name]
+	r.RUnlock()
+
+	return t, ok
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package battery
+
+import (
+	"os"
+	"syscall"
+)
+
+// inspectSignals dump the actor tree to stderr, e.g. `kill -USR1 <pid>`
+var inspectSignals = []os.Signal{syscall.SIGUSR1}
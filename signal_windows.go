@@ -0,0 +1,8 @@
+//go:build windows
+
+package battery
+
+import "os"
+
+// inspectSignals is empty, there is no SIGUSR1 on windows
+var inspectSignals []os.Signal